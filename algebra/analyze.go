@@ -0,0 +1,226 @@
+package algebra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report is what Analyze finds out about a table.  Where a property
+// doesn't hold, it keeps the first elements that show it.
+type Report struct {
+	c *Cayley
+
+	Closed bool
+	// Elements whose product isn't an element
+	Outside [2]int
+
+	Associative bool
+	// Elements where (a*b)*c != a*(b*c)
+	NonAssociative [3]int
+
+	Commutative bool
+	// Elements where a*b != b*a
+	NonCommutative [2]int
+
+	// Identity is the index of the identity, or OUTSIDE if there isn't one
+	Identity int
+	// Inverses are the index of an inverse of each element, or OUTSIDE,
+	// and nil without an identity
+	Inverses []int
+
+	// ElementOrders are the smallest k > 0 with a^k the identity for each
+	// element, or 0 if there isn't one.  They're nil unless closed with an
+	// identity.
+	ElementOrders []int
+}
+
+// Analyze checks which properties c's operation has.  Associativity is
+// only checked if it's closed.
+func (c *Cayley) Analyze() Report {
+	n, prod := c.Order(), c.Products
+	r := Report{c: c, Closed: true, Associative: true, Commutative: true,
+		Identity: OUTSIDE}
+
+	for a := range n {
+		for b := range n {
+			if r.Closed && prod[a][b] == OUTSIDE {
+				r.Closed, r.Outside = false, [2]int{a, b}
+			}
+			if r.Commutative && c.Product(a, b) != c.Product(b, a) {
+				r.Commutative, r.NonCommutative = false, [2]int{a, b}
+			}
+		}
+	}
+
+	if r.Closed {
+	assoc:
+		for a := range n {
+			for b := range n {
+				ab := prod[a][b]
+				for d := range n {
+					if prod[ab][d] != prod[a][prod[b][d]] {
+						r.Associative = false
+						r.NonAssociative = [3]int{a, b, d}
+						break assoc
+					}
+				}
+			}
+		}
+	} else {
+		r.Associative = false
+	}
+
+	// A two-sided identity is unique if there is one
+	for e := range n {
+		isIdentity := true
+		for a := range n {
+			if prod[e][a] != a || prod[a][e] != a {
+				isIdentity = false
+				break
+			}
+		}
+		if isIdentity {
+			r.Identity = e
+			break
+		}
+	}
+	if r.Identity == OUTSIDE {
+		return r
+	}
+
+	r.Inverses = make([]int, n)
+	for a := range n {
+		r.Inverses[a] = OUTSIDE
+		for b := range n {
+			if prod[a][b] == r.Identity && prod[b][a] == r.Identity {
+				r.Inverses[a] = b
+				break
+			}
+		}
+	}
+
+	if r.Closed {
+		r.ElementOrders = make([]int, n)
+		for a := range n {
+			// Powers past n have repeated, so would never reach it
+			for k, x := 1, a; k <= n; k, x = k+1, prod[x][a] {
+				if x == r.Identity {
+					r.ElementOrders[a] = k
+					break
+				}
+			}
+		}
+	}
+	return r
+}
+
+// HasInverses reports whether every element has an inverse.
+func (r Report) HasInverses() bool {
+	if r.Inverses == nil {
+		return false
+	}
+	for _, inv := range r.Inverses {
+		if inv == OUTSIDE {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGroup reports whether the table is a group.
+func (r Report) IsGroup() bool {
+	return r.Closed && r.Associative && r.HasInverses()
+}
+
+// IsAbelian reports whether the table is a commutative group.
+func (r Report) IsAbelian() bool {
+	return r.IsGroup() && r.Commutative
+}
+
+// String writes a line per property, giving the elements that show it
+// where one doesn't hold, like
+//
+//	order 3
+//	closed yes
+//	associative yes
+//	identity 0
+//	inverses 0:0 1:2 2:1
+//	commutative yes
+//	group yes, abelian
+//	element orders 0:1 1:3 2:3
+func (r Report) String() string {
+	c := r.c
+	e := c.Elements
+	op := func(a, b string) string {
+		return a + " " + c.Symbol + " " + b
+	}
+	lines := []string{fmt.Sprintf("order %v", c.Order())}
+
+	if r.Closed {
+		lines = append(lines, "closed yes")
+	} else {
+		a, b := r.Outside[0], r.Outside[1]
+		lines = append(lines, fmt.Sprintf("closed no, %v = %v",
+			op(e[a], e[b]), c.Product(a, b)))
+	}
+
+	switch {
+	case r.Associative:
+		lines = append(lines, "associative yes")
+	case !r.Closed:
+		lines = append(lines, "associative unknown, not closed")
+	default:
+		a, b, d := r.NonAssociative[0], r.NonAssociative[1],
+			r.NonAssociative[2]
+		ab, bd := c.Products[a][b], c.Products[b][d]
+		lines = append(lines, fmt.Sprintf(
+			"associative no, (%v) %v %v = %v but %v %v (%v) = %v",
+			op(e[a], e[b]), c.Symbol, e[d], c.Product(ab, d),
+			e[a], c.Symbol, op(e[b], e[d]), c.Product(a, bd)))
+	}
+
+	if r.Identity == OUTSIDE {
+		lines = append(lines, "identity none", "inverses none")
+	} else {
+		lines = append(lines, "identity "+e[r.Identity])
+		inverses := []string{}
+		for a, inv := range r.Inverses {
+			name := "none"
+			if inv != OUTSIDE {
+				name = e[inv]
+			}
+			inverses = append(inverses, e[a]+":"+name)
+		}
+		lines = append(lines, "inverses "+strings.Join(inverses, " "))
+	}
+
+	if r.Commutative {
+		lines = append(lines, "commutative yes")
+	} else {
+		a, b := r.NonCommutative[0], r.NonCommutative[1]
+		lines = append(lines, fmt.Sprintf("commutative no, %v = %v but %v = %v",
+			op(e[a], e[b]), c.Product(a, b), op(e[b], e[a]), c.Product(b, a)))
+	}
+
+	switch {
+	case r.IsAbelian():
+		lines = append(lines, "group yes, abelian")
+	case r.IsGroup():
+		lines = append(lines, "group yes")
+	default:
+		lines = append(lines, "group no")
+	}
+
+	if r.ElementOrders != nil {
+		orders := []string{}
+		for a, k := range r.ElementOrders {
+			order := "none"
+			if k > 0 {
+				order = fmt.Sprint(k)
+			}
+			orders = append(orders, e[a]+":"+order)
+		}
+		lines = append(lines, "element orders "+strings.Join(orders, " "))
+	}
+	return strings.Join(lines, "\n")
+}
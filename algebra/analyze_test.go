@@ -0,0 +1,103 @@
+package algebra
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	type Pair struct {
+		input    func() (*Cayley, error)
+		expected string
+	}
+
+	pairs := []Pair{
+		{func() (*Cayley, error) { return AdditiveMod(4) }, strings.Join([]string{
+			"order 4",
+			"closed yes",
+			"associative yes",
+			"identity 0",
+			"inverses 0:0 1:3 2:2 3:1",
+			"commutative yes",
+			"group yes, abelian",
+			"element orders 0:1 1:4 2:2 3:4",
+		}, "\n")},
+		{func() (*Cayley, error) { return MultiplicativeMod(4) }, strings.Join([]string{
+			"order 4",
+			"closed yes",
+			"associative yes",
+			"identity 1",
+			"inverses 0:none 1:1 2:none 3:3",
+			"commutative yes",
+			"group no",
+			"element orders 0:none 1:1 2:none 3:2",
+		}, "\n")},
+		{func() (*Cayley, error) { return Symmetric(3) }, strings.Join([]string{
+			"order 6",
+			"closed yes",
+			"associative yes",
+			"identity e",
+			"inverses e:e (23):(23) (12):(12) (123):(132) (132):(123) (13):(13)",
+			"commutative no, (23) o (12) = (132) but (12) o (23) = (123)",
+			"group yes",
+			"element orders e:1 (23):2 (12):2 (123):3 (132):3 (13):2",
+		}, "\n")},
+		// Rock, paper, scissors, with the winner as the product
+		{func() (*Cayley, error) {
+			return New("v", []string{"r", "p", "s"}, [][]string{
+				{"r", "p", "r"}, {"p", "p", "s"}, {"r", "s", "s"}})
+		}, strings.Join([]string{
+			"order 3",
+			"closed yes",
+			"associative no, (r v p) v s = s but r v (p v s) = r",
+			"identity none",
+			"inverses none",
+			"commutative yes",
+			"group no",
+		}, "\n")},
+		{func() (*Cayley, error) {
+			return New("+", []string{"0", "1"}, [][]string{{"0", "1"},
+				{"1", "2"}})
+		}, strings.Join([]string{
+			"order 2",
+			"closed no, 1 + 1 = 2",
+			"associative unknown, not closed",
+			"identity 0",
+			"inverses 0:0 1:none",
+			"commutative yes",
+			"group no",
+		}, "\n")},
+	}
+	for _, p := range pairs {
+		c, err := p.input()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Analyze().String(); got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				c.Elements, p.expected, got)
+		}
+	}
+}
+
+// TestGroupOrders checks Lagrange's theorem, that every element's order
+// divides the group's, across the groups of units.
+func TestGroupOrders(t *testing.T) {
+	for n := 1; n <= CAYLEY_ORDER_MAX; n++ {
+		c, err := UnitsMod(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := c.Analyze()
+		if !r.IsAbelian() {
+			t.Fatalf("Input: %#v\nExpected an abelian group\n     Got: %v\n",
+				n, r)
+		}
+		for a, k := range r.ElementOrders {
+			if k == 0 || c.Order()%k != 0 {
+				t.Fatalf("Input: %#v\nElement %v has order %v in a group "+
+					"of order %v\n", n, c.Elements[a], k, c.Order())
+			}
+		}
+	}
+}
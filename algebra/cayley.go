@@ -0,0 +1,184 @@
+// Package algebra builds Cayley tables of finite operations and checks what
+// structure they have.
+//
+// A Cayley table lists an operation's product for every pair of elements.
+// AdditiveMod, MultiplicativeMod and UnitsMod make them for modular
+// arithmetic, Symmetric and Generated for groups of permutations, and New
+// for any table written out by hand.  Analyze reports whether the operation
+// is closed, associative and commutative, whether it has an identity and
+// inverses, and so whether it's a group, along with the order of each
+// element.  Table renders it with the table package.
+package algebra
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/carbonizer/codeeval-go/primes"
+	"github.com/carbonizer/codeeval-go/table"
+)
+
+// CAYLEY_ORDER_MAX caps how many elements a table can have.  Checking
+// associativity takes the cube of that many products.
+const CAYLEY_ORDER_MAX = 256
+
+// OUTSIDE is a product's index when it isn't one of the elements.
+const OUTSIDE = -1
+
+// Cayley is the table of an operation on a finite set of elements.  Row a,
+// column b is the product a*b.
+type Cayley struct {
+	Symbol   string
+	Elements []string
+	// Products are the indexes into Elements of each product, or OUTSIDE
+	Products [][]int
+	// cells are the products by name, for those that are OUTSIDE
+	cells [][]string
+}
+
+// New makes the table of an operation on elements, from the name of each
+// product.  Products needn't be elements; Analyze reports the table as not
+// closed if they aren't.
+func New(symbol string, elements []string, cells [][]string) (*Cayley, error) {
+	n := len(elements)
+	if n == 0 || n > CAYLEY_ORDER_MAX {
+		return nil, fmt.Errorf("Cannot make a table of %v elements", n)
+	}
+	index := make(map[string]int, n)
+	for i, e := range elements {
+		if _, ok := index[e]; ok {
+			return nil, fmt.Errorf("Element %#v is repeated", e)
+		}
+		index[e] = i
+	}
+	if len(cells) != n {
+		return nil, fmt.Errorf("Expected %v rows, got %v", n, len(cells))
+	}
+
+	c := &Cayley{Symbol: symbol, Elements: elements,
+		Products: make([][]int, n), cells: cells}
+	for i, row := range cells {
+		if len(row) != n {
+			return nil, fmt.Errorf("Expected %v products for %#v, got %v",
+				n, elements[i], len(row))
+		}
+		c.Products[i] = make([]int, n)
+		for j, cell := range row {
+			k, ok := index[cell]
+			if !ok {
+				k = OUTSIDE
+			}
+			c.Products[i][j] = k
+		}
+	}
+	return c, nil
+}
+
+// fromFunc makes the table of an operation that's closed on elements, with
+// fn giving the index of each product.
+func fromFunc(symbol string, elements []string, fn func(a, b int) int) *Cayley {
+	n := len(elements)
+	c := &Cayley{Symbol: symbol, Elements: elements,
+		Products: make([][]int, n), cells: make([][]string, n)}
+	for a := range n {
+		c.Products[a] = make([]int, n)
+		c.cells[a] = make([]string, n)
+		for b := range n {
+			c.Products[a][b] = fn(a, b)
+			c.cells[a][b] = elements[c.Products[a][b]]
+		}
+	}
+	return c
+}
+
+// Order returns how many elements there are.
+func (c *Cayley) Order() int {
+	return len(c.Elements)
+}
+
+// Product returns a*b by name, even if it isn't an element.
+func (c *Cayley) Product(a, b int) string {
+	return c.cells[a][b]
+}
+
+// Table returns the table for rendering, elements along the top and side.
+// The values behind the products are their indexes, so a heatmap colours
+// each element the same wherever it is.
+func (c *Cayley) Table() *table.Table {
+	top := append([]string{c.Symbol}, c.Elements...)
+	cells := [][]string{top}
+	values := [][]float64{make([]float64, len(top))}
+	for a, e := range c.Elements {
+		cells = append(cells, append([]string{e}, c.cells[a]...))
+		row := []float64{math.NaN()}
+		for _, k := range c.Products[a] {
+			if k == OUTSIDE {
+				row = append(row, math.NaN())
+			} else {
+				row = append(row, float64(k))
+			}
+		}
+		values = append(values, row)
+	}
+	return &table.Table{Cells: cells, HeaderRow: true, HeaderCol: true,
+		Values: values}
+}
+
+// residues checks that n is a usable modulus, and returns the
+// names of 0 to n-1.
+func residues(n int) ([]string, error) {
+	if n < 1 || n > CAYLEY_ORDER_MAX {
+		return nil, fmt.Errorf("Cannot make a table mod %v", n)
+	}
+	rv := make([]string, n)
+	for i := range rv {
+		rv[i] = strconv.Itoa(i)
+	}
+	return rv, nil
+}
+
+// AdditiveMod returns the table of addition mod n, the cyclic group of
+// order n.
+func AdditiveMod(n int) (*Cayley, error) {
+	elements, err := residues(n)
+	if err != nil {
+		return nil, err
+	}
+	return fromFunc("+", elements, func(a, b int) int {
+		return (a + b) % n
+	}), nil
+}
+
+// MultiplicativeMod returns the table of multiplication mod n on all of 0
+// to n-1.  It's never a group past n = 1, as 0 has no inverse.
+func MultiplicativeMod(n int) (*Cayley, error) {
+	elements, err := residues(n)
+	if err != nil {
+		return nil, err
+	}
+	return fromFunc("*", elements, func(a, b int) int {
+		return a * b % n
+	}), nil
+}
+
+// UnitsMod returns the table of multiplication mod n on the residues
+// coprime to n, the group of units mod n.
+func UnitsMod(n int) (*Cayley, error) {
+	all, err := residues(n)
+	if err != nil {
+		return nil, err
+	}
+	units, elements, index := []int{}, []string{}, map[int]int{}
+	for i := range all {
+		// 0 is a unit only mod 1, where it's also 1
+		if primes.GCD(uint64(i), uint64(n)) == 1 || n == 1 {
+			index[i] = len(units)
+			units = append(units, i)
+			elements = append(elements, all[i])
+		}
+	}
+	return fromFunc("*", elements, func(a, b int) int {
+		return index[units[a]*units[b]%n]
+	}), nil
+}
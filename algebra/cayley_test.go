@@ -0,0 +1,112 @@
+package algebra
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/carbonizer/codeeval-go/table"
+)
+
+func TestCayley(t *testing.T) {
+	type Pair struct {
+		input    func() (*Cayley, error)
+		expected string
+	}
+
+	pairs := []Pair{
+		{func() (*Cayley, error) { return AdditiveMod(3) }, strings.Join([]string{
+			"+ | 0 1 2",
+			"--+------",
+			"0 | 0 1 2",
+			"1 | 1 2 0",
+			"2 | 2 0 1",
+		}, "\n")},
+		{func() (*Cayley, error) { return MultiplicativeMod(4) }, strings.Join([]string{
+			"* | 0 1 2 3",
+			"--+--------",
+			"0 | 0 0 0 0",
+			"1 | 0 1 2 3",
+			"2 | 0 2 0 2",
+			"3 | 0 3 2 1",
+		}, "\n")},
+		{func() (*Cayley, error) { return UnitsMod(8) }, strings.Join([]string{
+			"* | 1 3 5 7",
+			"--+--------",
+			"1 | 1 3 5 7",
+			"3 | 3 1 7 5",
+			"5 | 5 7 1 3",
+			"7 | 7 5 3 1",
+		}, "\n")},
+		{func() (*Cayley, error) { return UnitsMod(1) }, "* | 0\n--+--\n0 | 0"},
+		// Products outside the elements are shown as they are
+		{func() (*Cayley, error) {
+			return New("+", []string{"0", "1"}, [][]string{{"0", "1"},
+				{"1", "10"}})
+		}, strings.Join([]string{
+			"+ | 0  1",
+			"--+-----",
+			"0 | 0  1",
+			"1 | 1 10",
+		}, "\n")},
+	}
+	for _, p := range pairs {
+		c, err := p.input()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := table.String(table.Text{}, c.Table())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				c.Elements, p.expected, got)
+		}
+	}
+}
+
+func TestCayleyErrors(t *testing.T) {
+	type Input struct {
+		elements []string
+		cells    [][]string
+	}
+
+	inputs := []Input{
+		{[]string{}, [][]string{}},
+		{[]string{"a", "a"}, [][]string{{"a", "a"}, {"a", "a"}}},
+		{[]string{"a", "b"}, [][]string{{"a", "b"}}},
+		{[]string{"a", "b"}, [][]string{{"a", "b"}, {"a"}}},
+	}
+	for _, input := range inputs {
+		if _, err := New("*", input.elements, input.cells); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", input)
+		}
+	}
+	for _, n := range []int{0, -1, CAYLEY_ORDER_MAX + 1} {
+		if _, err := AdditiveMod(n); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", n)
+		}
+	}
+
+	// The largest allowed is fine
+	c, err := MultiplicativeMod(CAYLEY_ORDER_MAX)
+	if err != nil || c.Order() != CAYLEY_ORDER_MAX ||
+		!slices.Equal(c.Products[2][:3], []int{0, 2, 4}) {
+		t.Fatalf("Input: %#v\nGot: %v, %v\n", CAYLEY_ORDER_MAX, c, err)
+	}
+}
+
+func TestCayleyValues(t *testing.T) {
+	c, err := Symmetric(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := c.Table().Values
+	// Row (23), after the header row and column, is its products' indexes
+	if expected := []float64{1, 0, 4, 5, 2, 3}; !slices.Equal(values[2][1:],
+		expected) {
+		t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+			"(23)", expected, values[2][1:])
+	}
+}
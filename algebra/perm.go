@@ -0,0 +1,180 @@
+package algebra
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/combinatorics"
+)
+
+// PERM_POINTS_MAX caps how many points a permutation can move.
+const PERM_POINTS_MAX = 64
+
+// checkPoints checks that n is a usable number of points.
+func checkPoints(n int) error {
+	if n < 1 || n > PERM_POINTS_MAX {
+		return fmt.Errorf("Cannot permute %v points", n)
+	}
+	return nil
+}
+
+// Perm is a permutation of {0, 1, ..., n-1}, the image of each point.
+type Perm []uint
+
+// Compose returns p*q, the permutation doing q first and then p.
+func (p Perm) Compose(q Perm) Perm {
+	rv := make(Perm, len(p))
+	for i, x := range q {
+		rv[i] = p[x]
+	}
+	return rv
+}
+
+// IsIdentity reports whether p moves nothing.
+func (p Perm) IsIdentity() bool {
+	for i, x := range p {
+		if uint(i) != x {
+			return false
+		}
+	}
+	return true
+}
+
+// String writes p in cycle notation from 1, like "(132)(45)", leaving out
+// fixed points.  Points past 9 are separated by spaces, like "(1 10)".  The
+// identity is "e".
+func (p Perm) String() string {
+	sep := ""
+	if len(p) > 9 {
+		sep = " "
+	}
+	seen := make([]bool, len(p))
+	sb := &strings.Builder{}
+	for start := range p {
+		if seen[start] || p[start] == uint(start) {
+			continue
+		}
+		points := []string{}
+		for x := uint(start); !seen[x]; x = p[x] {
+			seen[x] = true
+			points = append(points, strconv.Itoa(int(x)+1))
+		}
+		sb.WriteString("(" + strings.Join(points, sep) + ")")
+	}
+	if sb.Len() == 0 {
+		return "e"
+	}
+	return sb.String()
+}
+
+// ParsePerm parses a permutation of n points in cycle notation, as String
+// writes them.  Points in a cycle can also be separated by commas.
+func ParsePerm(n int, s string) (Perm, error) {
+	if err := checkPoints(n); err != nil {
+		return nil, err
+	}
+	p := make(Perm, n)
+	for i := range p {
+		p[i] = uint(i)
+	}
+	if s == "e" {
+		return p, nil
+	}
+	errCannot := fmt.Errorf("Cannot parse permutation %#v of %v points", s, n)
+	if s == "" {
+		return nil, errCannot
+	}
+	moved := make([]bool, n)
+	for rest := s; rest != ""; {
+		cycle, after, found := strings.Cut(rest, ")")
+		if !found || !strings.HasPrefix(cycle, "(") {
+			return nil, errCannot
+		}
+		cycle, rest = cycle[1:], after
+
+		fields := strings.FieldsFunc(cycle, func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		// Without separators, each digit is a point
+		if len(fields) == 1 && n <= 9 {
+			fields = strings.Split(fields[0], "")
+		}
+		points := []uint{}
+		for _, field := range fields {
+			x, err := strconv.Atoi(field)
+			if err != nil || x < 1 || x > n || moved[x-1] {
+				return nil, errCannot
+			}
+			moved[x-1] = true
+			points = append(points, uint(x-1))
+		}
+		for i, x := range points {
+			p[x] = points[(i+1)%len(points)]
+		}
+	}
+	return p, nil
+}
+
+// permTable makes the table of composition on perms, which must be closed
+// under it.  The symbol is "o", for ∘ in ASCII.
+func permTable(perms []Perm) *Cayley {
+	elements := []string{}
+	index := map[string]int{}
+	for i, p := range perms {
+		elements = append(elements, p.String())
+		index[elements[i]] = i
+	}
+	return fromFunc("o", elements, func(a, b int) int {
+		return index[perms[a].Compose(perms[b]).String()]
+	})
+}
+
+// Symmetric returns the table of the symmetric group on n points, every
+// permutation in lexicographic order, the identity first.
+func Symmetric(n int) (*Cayley, error) {
+	// 5! is the most that fits under CAYLEY_ORDER_MAX
+	if n < 1 || n > 5 {
+		return nil, fmt.Errorf("Cannot make the symmetric group on %v points",
+			n)
+	}
+	perms := []Perm{}
+	for p := range combinatorics.Permutations(uint(n),
+		combinatorics.BUFFER_FRESH) {
+		perms = append(perms, Perm(p))
+	}
+	return permTable(perms), nil
+}
+
+// Generated returns the table of the group of permutations of n points
+// generated by gens.  Its elements are in the order they're reached from the
+// identity, multiplying by each generator in turn.
+func Generated(n int, gens []Perm) (*Cayley, error) {
+	identity, err := ParsePerm(n, "e")
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range gens {
+		if len(g) != n {
+			return nil, fmt.Errorf("Permutation %v isn't of %v points", g, n)
+		}
+	}
+	perms := []Perm{identity}
+	seen := map[string]bool{identity.String(): true}
+	// Breadth first, so each element comes after the shorter products
+	for i := 0; i < len(perms); i++ {
+		for _, g := range gens {
+			p := perms[i].Compose(g)
+			if seen[p.String()] {
+				continue
+			}
+			if len(perms) == CAYLEY_ORDER_MAX {
+				return nil, fmt.Errorf("Generators %v make more than %v "+
+					"elements", gens, CAYLEY_ORDER_MAX)
+			}
+			seen[p.String()] = true
+			perms = append(perms, p)
+		}
+	}
+	return permTable(perms), nil
+}
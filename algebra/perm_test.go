@@ -0,0 +1,150 @@
+package algebra
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPerm(t *testing.T) {
+	type Pair struct {
+		n        int
+		input    string
+		expected Perm
+	}
+
+	pairs := []Pair{
+		{3, "e", Perm{0, 1, 2}},
+		{3, "(12)", Perm{1, 0, 2}},
+		{3, "(123)", Perm{1, 2, 0}},
+		{5, "(132)(45)", Perm{2, 0, 1, 4, 3}},
+		{10, "(1 10)", Perm{9, 1, 2, 3, 4, 5, 6, 7, 8, 0}},
+	}
+	for _, p := range pairs {
+		got, err := ParsePerm(p.n, p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, p.expected) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, got)
+		}
+		if got.String() != p.input {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.expected, p.input, got.String())
+		}
+	}
+
+	// Commas separate points too, and a 1-cycle is no cycle
+	if p, err := ParsePerm(3, "(1,3)(2)"); err != nil || p.String() != "(13)" {
+		t.Fatalf("Input: %#v\nGot: %v, %v\n", "(1,3)(2)", p, err)
+	}
+	for _, s := range []string{"", "12", "(12", "(11)", "(12)(23)", "(14)",
+		"(1a)", "x(12)"} {
+		if _, err := ParsePerm(3, s); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", s)
+		}
+	}
+	for _, n := range []int{-1, 0, PERM_POINTS_MAX + 1} {
+		if _, err := ParsePerm(n, "e"); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", n)
+		}
+		if _, err := Generated(n, nil); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", n)
+		}
+	}
+}
+
+func TestCompose(t *testing.T) {
+	a, _ := ParsePerm(3, "(12)")
+	b, _ := ParsePerm(3, "(23)")
+	// b first, then a: 1 -> 1 -> 2, 2 -> 3 -> 3, 3 -> 2 -> 1
+	if got := a.Compose(b).String(); got != "(123)" {
+		t.Fatalf("Input: %v o %v\nExpected: %#v\n     Got: %#v\n",
+			a, b, "(123)", got)
+	}
+	if got := b.Compose(a).String(); got != "(132)" {
+		t.Fatalf("Input: %v o %v\nExpected: %#v\n     Got: %#v\n",
+			b, a, "(132)", got)
+	}
+	if !a.Compose(a).IsIdentity() || a.IsIdentity() {
+		t.Fatalf("Input: %v\nExpected an involution", a)
+	}
+}
+
+func TestPermGroups(t *testing.T) {
+	s3, err := Symmetric(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"e", "(23)", "(12)", "(123)", "(132)", "(13)"}
+	if !slices.Equal(s3.Elements, expected) {
+		t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+			3, expected, s3.Elements)
+	}
+	if got := s3.Product(1, 2); got != "(132)" {
+		t.Fatalf("Input: (23) o (12)\nExpected: %#v\n     Got: %#v\n",
+			"(132)", got)
+	}
+	for n, order := range []int{0, 1, 2, 6, 24, 120} {
+		if n == 0 {
+			continue
+		}
+		c, err := Symmetric(n)
+		if err != nil || c.Order() != order {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %v, %v\n",
+				n, order, c, err)
+		}
+	}
+	for _, n := range []int{0, 6} {
+		if _, err := Symmetric(n); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", n)
+		}
+	}
+
+	type Pair struct {
+		n        int
+		gens     []string
+		expected []string
+	}
+
+	pairs := []Pair{
+		{4, []string{"(1234)"}, []string{"e", "(1234)", "(13)(24)", "(1432)"}},
+		{4, []string{"(12)(34)", "(13)(24)"},
+			[]string{"e", "(12)(34)", "(13)(24)", "(14)(23)"}},
+		{3, []string{}, []string{"e"}},
+	}
+	for _, p := range pairs {
+		gens := []Perm{}
+		for _, s := range p.gens {
+			g, err := ParsePerm(p.n, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gens = append(gens, g)
+		}
+		c, err := Generated(p.n, gens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(c.Elements, p.expected) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.gens, p.expected, c.Elements)
+		}
+	}
+
+	// (12) and (12...n) generate all n! of them
+	g1, _ := ParsePerm(5, "(12)")
+	g2, _ := ParsePerm(5, "(12345)")
+	if c, err := Generated(5, []Perm{g1, g2}); err != nil || c.Order() != 120 {
+		t.Fatalf("Input: %v %v\nExpected: 120\n     Got: %v, %v\n",
+			g1, g2, c, err)
+	}
+	g3, _ := ParsePerm(6, "(12)")
+	g4, _ := ParsePerm(6, "(123456)")
+	if _, err := Generated(6, []Perm{g3, g4}); err == nil {
+		t.Fatalf("Input: %v %v\nExpected an error", g3, g4)
+	}
+	if _, err := Generated(6, []Perm{g1}); err == nil {
+		t.Fatalf("Input: %v\nExpected an error", g1)
+	}
+}
@@ -0,0 +1,154 @@
+// Package combinatorics counts, enumerates and ranks the ways elements can be
+// chosen and arranged.
+//
+// The plain functions work with uint64 and return ErrOverflow instead of
+// silently wrapping around.  The Big* functions use math/big for when the
+// exact answer is needed no matter how large it gets.
+package combinatorics
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// ErrOverflow is returned when a result does not fit in a uint64.
+var ErrOverflow = errors.New("combinatorics: result overflows uint64")
+
+// mul returns a * b, or ErrOverflow if the product doesn't fit in a uint64.
+func mul(a, b uint64) (uint64, error) {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return 0, ErrOverflow
+	}
+	return lo, nil
+}
+
+// mulDiv returns a * b / c without overflowing the intermediate product.
+// The caller must know that c divides a * b exactly.
+func mulDiv(a, b, c uint64) (uint64, error) {
+	hi, lo := bits.Mul64(a, b)
+	// The quotient only fits in 64 bits if the high word is less than c
+	if hi >= c {
+		return 0, ErrOverflow
+	}
+	q, _ := bits.Div64(hi, lo, c)
+	return q, nil
+}
+
+// Factorial calculates the product of (n, n-1, n-2, ..., 3, 2).
+// 20! is the largest factorial that fits in a uint64.
+func Factorial(n uint64) (uint64, error) {
+	rv := uint64(1)
+	for ; n > 1; n-- {
+		var err error
+		if rv, err = mul(rv, n); err != nil {
+			return 0, err
+		}
+	}
+	return rv, nil
+}
+
+// Combination calculates the number of combos when choosing k elems from n.
+// There is NO replacement, and the order of the elements doesn't matter (the
+// same elements in a different order is considered the same combination).
+//
+// Rather than dividing factorials, the result is built up one factor at a
+// time, so it only overflows if the answer itself doesn't fit.
+func Combination(n, k uint64) (uint64, error) {
+	if k > n {
+		return 0, nil
+	}
+	// C(n, k) == C(n, n-k), so use whichever needs fewer steps
+	if n-k < k {
+		k = n - k
+	}
+
+	rv := uint64(1)
+	for i := uint64(1); i <= k; i++ {
+		// After this step, rv == C(n-k+i, i), which is always an integer
+		var err error
+		if rv, err = mulDiv(rv, n-k+i, i); err != nil {
+			return 0, err
+		}
+	}
+	return rv, nil
+}
+
+// CombinationReplacement calculates combination with replacement.
+func CombinationReplacement(n, k uint64) (uint64, error) {
+	switch {
+	case k == 0:
+		return 1, nil
+	case n == 0:
+		return 0, nil
+	case n+k-1 < n:
+		return 0, ErrOverflow
+	}
+	return Combination(n+k-1, k)
+}
+
+// Permutation calculates the number of ways k elems can be selected from n.
+// There is NO replacement, but order is important (the same elements in a
+// different order is a different permutation).
+func Permutation(n, k uint64) (uint64, error) {
+	if k > n {
+		return 0, nil
+	}
+	rv := uint64(1)
+	// Counting up to k rather than up to n, which could wrap around
+	for j := uint64(0); j < k; j++ {
+		var err error
+		if rv, err = mul(rv, n-j); err != nil {
+			return 0, err
+		}
+	}
+	return rv, nil
+}
+
+// BigFactorial is Factorial without the upper limit.
+func BigFactorial(n uint64) *big.Int {
+	if n < 2 {
+		return big.NewInt(1)
+	}
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// BigCombination is Combination without the upper limit.
+func BigCombination(n, k uint64) *big.Int {
+	if k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// BigCombinationReplacement is CombinationReplacement without the upper limit.
+func BigCombinationReplacement(n, k uint64) *big.Int {
+	switch {
+	case k == 0:
+		return big.NewInt(1)
+	case n == 0:
+		return new(big.Int)
+	}
+	return BigCombination(n+k-1, k)
+}
+
+// BigPermutation is Permutation without the upper limit.
+func BigPermutation(n, k uint64) *big.Int {
+	switch {
+	case k > n:
+		return new(big.Int)
+	case k == 0:
+		return big.NewInt(1)
+	}
+	if n <= math.MaxInt64 {
+		return new(big.Int).MulRange(int64(n-k+1), int64(n))
+	}
+	// MulRange only takes int64s
+	rv, factor := big.NewInt(1), new(big.Int)
+	for j := uint64(0); j < k; j++ {
+		rv.Mul(rv, factor.SetUint64(n-j))
+	}
+	return rv
+}
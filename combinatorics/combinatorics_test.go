@@ -0,0 +1,169 @@
+package combinatorics
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFactorial(t *testing.T) {
+	type Pair struct {
+		input, expected uint64
+	}
+
+	pairs := []Pair{{0, 1}, {1, 1}, {2, 2}, {3, 6}, {7, 5040},
+		{20, 2432902008176640000}}
+	for _, p := range pairs {
+		result, err := Factorial(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	if _, err := Factorial(21); err != ErrOverflow {
+		t.Fatalf("Expected ErrOverflow for 21!, got %v", err)
+	}
+}
+
+func TestCombination(t *testing.T) {
+	type Args struct {
+		n, k uint64
+	}
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{
+		{Args{0, 0}, 1},
+		{Args{3, 4}, 0},
+		{Args{5, 3}, 10},
+		{Args{52, 5}, 2598960},
+		// Far past the point where 21! would have overflowed
+		{Args{62, 31}, 465428353255261088},
+		{Args{67, 33}, 14226520737620288370},
+	}
+	for _, p := range pairs {
+		result, err := Combination(p.input.n, p.input.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	if _, err := Combination(68, 34); err != ErrOverflow {
+		t.Fatalf("Expected ErrOverflow for C(68, 34), got %v", err)
+	}
+}
+
+func TestCombinationMatchesBig(t *testing.T) {
+	for n := uint64(0); n <= 70; n++ {
+		for k := uint64(0); k <= n+1; k++ {
+			expected := BigCombination(n, k)
+			result, err := Combination(n, k)
+			switch {
+			case !expected.IsUint64():
+				if err != ErrOverflow {
+					t.Fatalf("C(%d, %d): expected ErrOverflow, "+
+						"got %v, %v", n, k, result, err)
+				}
+			case err != nil || expected.Uint64() != result:
+				t.Fatalf("C(%d, %d): expected %v, got %v, %v",
+					n, k, expected, result, err)
+			}
+		}
+	}
+}
+
+func TestCombinationReplacement(t *testing.T) {
+	type Args struct {
+		n, k uint64
+	}
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{
+		{Args{0, 0}, 1},
+		{Args{0, 2}, 0},
+		{Args{3, 2}, 6},
+		{Args{5, 3}, 35},
+	}
+	for _, p := range pairs {
+		result, err := CombinationReplacement(p.input.n, p.input.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+		big := BigCombinationReplacement(p.input.n, p.input.k)
+		if big.Uint64() != result {
+			t.Fatalf("Input: %#v\nExpected: %v\n     Got: %v\n",
+				p.input, result, big)
+		}
+	}
+
+	if _, err := CombinationReplacement(1<<63, 1<<63); err != ErrOverflow {
+		t.Fatalf("Expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	type Args struct {
+		n, k uint64
+	}
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{
+		{Args{5, 0}, 1},
+		{Args{5, 6}, 0},
+		{Args{5, 2}, 20},
+		{Args{10, 10}, 3628800},
+		{Args{25, 13}, 32382376266240000},
+		// Counting up to n would wrap around here
+		{Args{math.MaxUint64, 0}, 1},
+		{Args{math.MaxUint64, 1}, math.MaxUint64},
+	}
+	for _, p := range pairs {
+		result, err := Permutation(p.input.n, p.input.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+		big := BigPermutation(p.input.n, p.input.k)
+		if big.Uint64() != result {
+			t.Fatalf("Input: %#v\nExpected: %v\n     Got: %v\n",
+				p.input, result, big)
+		}
+	}
+
+	if _, err := Permutation(25, 20); err != ErrOverflow {
+		t.Fatalf("Expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestBigFactorial(t *testing.T) {
+	expected, _ := new(big.Int).SetString("51090942171709440000", 10)
+	if result := BigFactorial(21); result.Cmp(expected) != 0 {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+	if result := BigFactorial(0); result.Int64() != 1 {
+		t.Fatalf("Expected 1, got %v", result)
+	}
+}
@@ -0,0 +1,284 @@
+package combinatorics
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/carbonizer/codeeval-go/primes"
+)
+
+// MOD_PRIME is the modulus most challenge problems ask for answers under.
+const MOD_PRIME = 1000000007
+
+// ErrModulus is returned when a modulus can't be used, e.g. it is 0 or too
+// small for the factorial table.
+var ErrModulus = errors.New("combinatorics: unusable modulus")
+
+// fit returns x as a uint64, or ErrOverflow if it is too big.
+func fit(x *big.Int) (uint64, error) {
+	if !x.IsUint64() {
+		return 0, ErrOverflow
+	}
+	return x.Uint64(), nil
+}
+
+//
+// Sequences
+//
+
+// BigCatalan returns the n'th Catalan number, C(2n, n) / (n+1).
+func BigCatalan(n uint64) *big.Int {
+	rv := BigCombination(2*n, n)
+	return rv.Quo(rv, new(big.Int).SetUint64(n+1))
+}
+
+// Catalan is BigCatalan, or ErrOverflow if the result doesn't fit.
+func Catalan(n uint64) (uint64, error) {
+	return fit(BigCatalan(n))
+}
+
+// BigStirling1 returns the unsigned Stirling number of the first kind, the
+// number of permutations of n elements with exactly k cycles.
+func BigStirling1(n, k uint64) *big.Int {
+	// c(n, k) = c(n-1, k-1) + (n-1) c(n-1, k), one row at a time
+	return stirlingRow(n, k, func(i, j uint64) uint64 { return i - 1 })
+}
+
+// Stirling1 is BigStirling1, or ErrOverflow if the result doesn't fit.
+func Stirling1(n, k uint64) (uint64, error) {
+	return fit(BigStirling1(n, k))
+}
+
+// BigStirling2 returns the Stirling number of the second kind, the number of
+// ways to partition n elements into exactly k non-empty subsets.
+func BigStirling2(n, k uint64) *big.Int {
+	// S(n, k) = S(n-1, k-1) + k S(n-1, k), one row at a time
+	return stirlingRow(n, k, func(i, j uint64) uint64 { return j })
+}
+
+// Stirling2 is BigStirling2, or ErrOverflow if the result doesn't fit.
+func Stirling2(n, k uint64) (uint64, error) {
+	return fit(BigStirling2(n, k))
+}
+
+// stirlingRow handles the recurrence both kinds of Stirling numbers share,
+// s(i, j) = s(i-1, j-1) + factor(i, j) s(i-1, j), and returns s(n, k).
+func stirlingRow(n, k uint64, factor func(i, j uint64) uint64) *big.Int {
+	if k > n {
+		return new(big.Int)
+	}
+	// row[j] is s(i, j).  Only columns up to k are ever needed.
+	row := make([]*big.Int, k+1)
+	for j := range row {
+		row[j] = new(big.Int)
+	}
+	row[0].SetInt64(1)
+	f := new(big.Int)
+	for i := uint64(1); i <= n; i++ {
+		// Go right to left so row[j-1] still holds the previous row
+		for j := min(i, k); j >= 1; j-- {
+			f.SetUint64(factor(i, j))
+			row[j].Mul(row[j], f).Add(row[j], row[j-1])
+		}
+		row[0].SetInt64(0)
+	}
+	return row[k]
+}
+
+// BigBell returns the n'th Bell number, the number of ways to partition n
+// elements into any number of non-empty subsets.
+func BigBell(n uint64) *big.Int {
+	// Bell triangle: each row starts with the last entry of the previous
+	// row, and each entry adds the one before it to the one above that.
+	row := []*big.Int{big.NewInt(1)}
+	for i := uint64(0); i < n; i++ {
+		next := make([]*big.Int, len(row)+1)
+		next[0] = row[len(row)-1]
+		for j, above := range row {
+			next[j+1] = new(big.Int).Add(next[j], above)
+		}
+		row = next
+	}
+	return row[0]
+}
+
+// Bell is BigBell, or ErrOverflow if the result doesn't fit.
+func Bell(n uint64) (uint64, error) {
+	return fit(BigBell(n))
+}
+
+// BigPartitions returns p(n), the number of ways to write n as a sum of
+// positive integers when order doesn't matter.
+func BigPartitions(n uint64) *big.Int {
+	// Euler's pentagonal number theorem:
+	// p(i) = sum over k of (-1)^(k+1) (p(i - k(3k-1)/2) + p(i - k(3k+1)/2))
+	p := make([]*big.Int, n+1)
+	p[0] = big.NewInt(1)
+	for i := uint64(1); i <= n; i++ {
+		p[i] = new(big.Int)
+		for k := uint64(1); ; k++ {
+			g1 := k * (3*k - 1) / 2
+			if g1 > i {
+				break
+			}
+			terms := new(big.Int).Set(p[i-g1])
+			if g2 := g1 + k; g2 <= i {
+				terms.Add(terms, p[i-g2])
+			}
+			if k%2 == 1 {
+				p[i].Add(p[i], terms)
+			} else {
+				p[i].Sub(p[i], terms)
+			}
+		}
+	}
+	return p[n]
+}
+
+// Partitions is BigPartitions, or ErrOverflow if the result doesn't fit.
+func Partitions(n uint64) (uint64, error) {
+	return fit(BigPartitions(n))
+}
+
+// BigDerangements returns !n, the number of permutations of n elements where
+// no element stays in its original place.
+func BigDerangements(n uint64) *big.Int {
+	// !i = (i-1) (!(i-1) + !(i-2)), starting from !0 = 1 and !1 = 0
+	prev, cur := big.NewInt(1), big.NewInt(0)
+	if n == 0 {
+		return prev
+	}
+	for i := uint64(2); i <= n; i++ {
+		next := new(big.Int).Add(prev, cur)
+		next.Mul(next, new(big.Int).SetUint64(i-1))
+		prev, cur = cur, next
+	}
+	return cur
+}
+
+// Derangements is BigDerangements, or ErrOverflow if the result doesn't fit.
+func Derangements(n uint64) (uint64, error) {
+	return fit(BigDerangements(n))
+}
+
+// Multinomial returns the number of distinct ways to order items where
+// counts[i] of them are identical copies of the i'th kind:
+// (sum counts)! / (counts[0]! counts[1]! ...).
+//
+// It is calculated as a product of binomials, so it only overflows if the
+// answer itself doesn't fit.
+func Multinomial(counts ...uint64) (uint64, error) {
+	return arrangements(counts)
+}
+
+// BigMultinomial is Multinomial without the upper limit.
+func BigMultinomial(counts ...uint64) *big.Int {
+	rv, total := big.NewInt(1), uint64(0)
+	for _, count := range counts {
+		total += count
+		rv.Mul(rv, BigCombination(total, count))
+	}
+	return rv
+}
+
+//
+// Modular arithmetic
+//
+
+// BinomialModPrime returns C(n, k) % p using Lucas' theorem: write n and k
+// in base p, and the result is the product of C(n_i, k_i) % p over each pair
+// of digits.  p must be prime, but it doesn't need to be bigger than n.
+func BinomialModPrime(n, k, p uint64) (uint64, error) {
+	if p < 2 {
+		return 0, ErrModulus
+	}
+	rv := uint64(1)
+	for ; n > 0 || k > 0; n, k = n/p, k/p {
+		ni, ki := n%p, k%p
+		if ki > ni {
+			// C(ni, ki) is 0, so the whole product is too
+			return 0, nil
+		}
+		rv = primes.MulMod(rv, smallBinomialMod(ni, ki, p), p)
+	}
+	return rv % p, nil
+}
+
+// smallBinomialMod returns C(n, k) % p for n < p.  Since none of the
+// factors are multiples of p, the denominator has an inverse by Fermat's
+// little theorem.
+func smallBinomialMod(n, k, p uint64) uint64 {
+	if n-k < k {
+		k = n - k
+	}
+	num, den := uint64(1), uint64(1)
+	for i := uint64(0); i < k; i++ {
+		num = primes.MulMod(num, n-i, p)
+		den = primes.MulMod(den, i+1, p)
+	}
+	return primes.MulMod(num, primes.PowMod(den, p-2, p), p)
+}
+
+// FactorialTable holds n! and its inverse modulo a prime for every n up to
+// some limit, so binomials and friends are O(1) each afterwards.
+type FactorialTable struct {
+	mod           uint64
+	fact, invFact []uint64
+}
+
+// NewFactorialTable builds a table of factorials up to n! modulo mod, which
+// must be a prime greater than n (MOD_PRIME is the usual choice).
+func NewFactorialTable(n, mod uint64) (*FactorialTable, error) {
+	if mod < 2 || mod <= n {
+		return nil, ErrModulus
+	}
+	ft := &FactorialTable{
+		mod:     mod,
+		fact:    make([]uint64, n+1),
+		invFact: make([]uint64, n+1),
+	}
+	ft.fact[0] = 1
+	for i := uint64(1); i <= n; i++ {
+		ft.fact[i] = primes.MulMod(ft.fact[i-1], i, mod)
+	}
+	// Only one modular inverse is needed.  The rest come from
+	// 1/(i-1)! = i * 1/i!
+	ft.invFact[n] = primes.PowMod(ft.fact[n], mod-2, mod)
+	for i := n; i > 0; i-- {
+		ft.invFact[i-1] = primes.MulMod(ft.invFact[i], i, mod)
+	}
+	return ft, nil
+}
+
+// Factorial returns n! % mod.  n must be within the table.
+func (ft *FactorialTable) Factorial(n uint64) uint64 {
+	return ft.fact[n]
+}
+
+// Combination returns C(n, k) % mod.  n must be within the table.
+func (ft *FactorialTable) Combination(n, k uint64) uint64 {
+	if k > n {
+		return 0
+	}
+	rv := primes.MulMod(ft.fact[n], ft.invFact[k], ft.mod)
+	return primes.MulMod(rv, ft.invFact[n-k], ft.mod)
+}
+
+// Permutation returns P(n, k) % mod.  n must be within the table.
+func (ft *FactorialTable) Permutation(n, k uint64) uint64 {
+	if k > n {
+		return 0
+	}
+	return primes.MulMod(ft.fact[n], ft.invFact[n-k], ft.mod)
+}
+
+// Multinomial returns Multinomial(counts...) % mod.  The sum of counts must
+// be within the table.
+func (ft *FactorialTable) Multinomial(counts ...uint64) uint64 {
+	total, rv := uint64(0), uint64(1)
+	for _, count := range counts {
+		total += count
+		rv = primes.MulMod(rv, ft.invFact[count], ft.mod)
+	}
+	return primes.MulMod(rv, ft.fact[total], ft.mod)
+}
@@ -0,0 +1,174 @@
+package combinatorics
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSequences(t *testing.T) {
+	type Pair struct {
+		name     string
+		fn       func() (uint64, error)
+		expected uint64
+	}
+
+	pairs := []Pair{
+		{"Catalan(0)", func() (uint64, error) { return Catalan(0) }, 1},
+		{"Catalan(5)", func() (uint64, error) { return Catalan(5) }, 42},
+		{"Catalan(36)", func() (uint64, error) { return Catalan(36) },
+			11959798385860453492},
+		{"Stirling1(5, 2)",
+			func() (uint64, error) { return Stirling1(5, 2) }, 50},
+		{"Stirling1(10, 3)",
+			func() (uint64, error) { return Stirling1(10, 3) }, 1172700},
+		{"Stirling1(0, 0)",
+			func() (uint64, error) { return Stirling1(0, 0) }, 1},
+		{"Stirling2(5, 2)",
+			func() (uint64, error) { return Stirling2(5, 2) }, 15},
+		{"Stirling2(10, 3)",
+			func() (uint64, error) { return Stirling2(10, 3) }, 9330},
+		{"Stirling2(3, 4)",
+			func() (uint64, error) { return Stirling2(3, 4) }, 0},
+		{"Bell(0)", func() (uint64, error) { return Bell(0) }, 1},
+		{"Bell(6)", func() (uint64, error) { return Bell(6) }, 203},
+		{"Bell(25)", func() (uint64, error) { return Bell(25) },
+			4638590332229999353},
+		{"Partitions(0)", func() (uint64, error) { return Partitions(0) }, 1},
+		{"Partitions(5)", func() (uint64, error) { return Partitions(5) }, 7},
+		{"Partitions(100)",
+			func() (uint64, error) { return Partitions(100) }, 190569292},
+		{"Derangements(0)",
+			func() (uint64, error) { return Derangements(0) }, 1},
+		{"Derangements(1)",
+			func() (uint64, error) { return Derangements(1) }, 0},
+		{"Derangements(4)",
+			func() (uint64, error) { return Derangements(4) }, 9},
+		{"Derangements(10)",
+			func() (uint64, error) { return Derangements(10) }, 1334961},
+		{"Multinomial(2, 1, 2)",
+			func() (uint64, error) { return Multinomial(2, 1, 2) }, 30},
+		{"Multinomial()",
+			func() (uint64, error) { return Multinomial() }, 1},
+	}
+	for _, p := range pairs {
+		result, err := p.fn()
+		if err != nil {
+			t.Fatalf("Input: %v\nError: %v\n", p.name, err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %v\nExpected: %#v\n     Got: %#v\n",
+				p.name, p.expected, result)
+		}
+	}
+}
+
+func TestSequencesOverflow(t *testing.T) {
+	type Pair struct {
+		name string
+		fn   func() (uint64, error)
+	}
+
+	pairs := []Pair{
+		{"Catalan(37)", func() (uint64, error) { return Catalan(37) }},
+		{"Stirling1(30, 1)",
+			func() (uint64, error) { return Stirling1(30, 1) }},
+		{"Stirling2(40, 20)",
+			func() (uint64, error) { return Stirling2(40, 20) }},
+		{"Bell(26)", func() (uint64, error) { return Bell(26) }},
+		{"Partitions(500)",
+			func() (uint64, error) { return Partitions(500) }},
+		{"Derangements(21)",
+			func() (uint64, error) { return Derangements(21) }},
+		{"Multinomial(40, 40)",
+			func() (uint64, error) { return Multinomial(40, 40) }},
+		{"Multinomial(2^63, 2^63)",
+			func() (uint64, error) { return Multinomial(1<<63, 1<<63) }},
+	}
+	for _, p := range pairs {
+		if _, err := p.fn(); err != ErrOverflow {
+			t.Fatalf("%v: expected ErrOverflow, got %v", p.name, err)
+		}
+	}
+
+	// The big versions keep going
+	expected, _ := new(big.Int).SetString("45950804324621742364", 10)
+	if result := BigCatalan(37); result.Cmp(expected) != 0 {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+	if result := BigMultinomial(40, 40); result.Cmp(
+		BigCombination(80, 40)) != 0 {
+		t.Fatalf("Expected %v, got %v", BigCombination(80, 40), result)
+	}
+}
+
+func TestBinomialModPrime(t *testing.T) {
+	type Args struct {
+		n, k, p uint64
+	}
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{
+		{Args{1000, 300, 13}, 10},
+		{Args{10, 3, 7}, 1},
+		// A digit of k is bigger than the matching digit of n
+		{Args{10, 4, 3}, 0},
+		{Args{5, 6, 7}, 0},
+	}
+	for _, p := range pairs {
+		result, err := BinomialModPrime(p.input.n, p.input.k, p.input.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	// Check against the exact value for a prime bigger than n too
+	for n := uint64(0); n < 40; n++ {
+		for k := uint64(0); k <= n; k++ {
+			for _, prime := range []uint64{2, 3, 5, 31, MOD_PRIME} {
+				exact := BigCombination(n, k)
+				expected := exact.Mod(exact,
+					new(big.Int).SetUint64(prime)).Uint64()
+				result, _ := BinomialModPrime(n, k, prime)
+				if expected != result {
+					t.Fatalf("C(%d, %d) %% %d: expected %v, got %v",
+						n, k, prime, expected, result)
+				}
+			}
+		}
+	}
+}
+
+func TestFactorialTable(t *testing.T) {
+	ft, err := NewFactorialTable(100000, MOD_PRIME)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := ft.Combination(100000, 50000); result != 149033233 {
+		t.Fatalf("Expected %v, got %v", 149033233, result)
+	}
+	if result := ft.Factorial(20); result != 2432902008176640000%MOD_PRIME {
+		t.Fatalf("Expected %v, got %v",
+			2432902008176640000%MOD_PRIME, result)
+	}
+	if result := ft.Permutation(10, 3); result != 720 {
+		t.Fatalf("Expected %v, got %v", 720, result)
+	}
+	if result := ft.Multinomial(2, 1, 2); result != 30 {
+		t.Fatalf("Expected %v, got %v", 30, result)
+	}
+	if result := ft.Combination(3, 4); result != 0 {
+		t.Fatalf("Expected %v, got %v", 0, result)
+	}
+
+	if _, err := NewFactorialTable(13, 13); err != ErrModulus {
+		t.Fatalf("Expected ErrModulus, got %v", err)
+	}
+}
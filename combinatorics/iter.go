@@ -0,0 +1,222 @@
+package combinatorics
+
+import (
+	"iter"
+	"slices"
+)
+
+// BufferMode controls what slice the iterators hand to the caller.
+type BufferMode int
+
+const (
+	// A newly allocated slice is yielded every time.  It is safe to keep
+	// or modify.
+	BUFFER_FRESH BufferMode = iota
+	// The same slice is overwritten and yielded every time.  This saves
+	// an allocation per item, but the caller has to copy anything it
+	// wants to hold on to (and must not modify it).
+	BUFFER_REUSE
+)
+
+// enumerate yields buf, then keeps stepping it with next until there is
+// nothing left.  Only buf itself is ever kept, so memory stays at O(len(buf))
+// no matter how many items there are.
+func enumerate(buf []uint, mode BufferMode, next func() bool,
+	yield func([]uint) bool) {
+	for {
+		out := buf
+		if mode == BUFFER_FRESH {
+			out = slices.Clone(buf)
+		}
+		if !yield(out) || !next() {
+			return
+		}
+	}
+}
+
+// iota_ returns {0, 1, ..., k-1}.
+func iota_(k uint) []uint {
+	rv := make([]uint, k)
+	for i := range rv {
+		rv[i] = uint(i)
+	}
+	return rv
+}
+
+// NextCombination steps c to the next k-combination of {0, 1, ..., n-1} in
+// lexicographic order, where k is len(c).  It returns false (leaving c
+// alone) if c was already the last one.
+func NextCombination(c []uint, n uint) bool {
+	k := uint(len(c))
+	// Find the rightmost element that hasn't hit its maximum.  Element i
+	// can go no higher than n-k+i, or there wouldn't be room for the
+	// greater elements after it.
+	i := int(k) - 1
+	for ; i >= 0 && c[i] == n-k+uint(i); i-- {
+	}
+	if i < 0 {
+		return false
+	}
+	c[i]++
+	// Everything after it restarts as low as possible
+	for j := i + 1; j < len(c); j++ {
+		c[j] = c[j-1] + 1
+	}
+	return true
+}
+
+// NextCombinationReplacement is NextCombination with replacement.
+func NextCombinationReplacement(c []uint, n uint) bool {
+	i := len(c) - 1
+	for ; i >= 0 && c[i] == n-1; i-- {
+	}
+	if i < 0 {
+		return false
+	}
+	c[i]++
+	// Replacement is allowed, so everything after it can repeat it
+	for j := i + 1; j < len(c); j++ {
+		c[j] = c[i]
+	}
+	return true
+}
+
+// NextPermutation rearranges p into the next permutation in lexicographic
+// order.  It returns false (leaving p alone) if p was already the last one.
+// Repeated values are fine; each distinct arrangement only comes up once.
+func NextPermutation(p []uint) bool {
+	// Find the start of the longest non-increasing suffix
+	i := len(p) - 1
+	for ; i > 0 && p[i-1] >= p[i]; i-- {
+	}
+	if i <= 0 {
+		return false
+	}
+	// p[i-1] is the pivot.  Swap it with the smallest greater value in
+	// the suffix, then put the suffix back in ascending order.
+	j := len(p) - 1
+	for p[j] <= p[i-1] {
+		j--
+	}
+	p[i-1], p[j] = p[j], p[i-1]
+	slices.Reverse(p[i:])
+	return true
+}
+
+// contains reports whether v is in s.  The slices involved are k long at
+// most, so this is cheaper than keeping an n-sized set around.
+func contains(s []uint, v uint) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// NextKPermutation steps p to the next k-permutation of {0, 1, ..., n-1} in
+// lexicographic order, where k is len(p).  It returns false (leaving p
+// alone) if p was already the last one.
+func NextKPermutation(p []uint, n uint) bool {
+	for i := len(p) - 1; i >= 0; i-- {
+		// Anything not used before position i is fair game for it
+		for v := p[i] + 1; v < n; v++ {
+			if contains(p[:i], v) {
+				continue
+			}
+			p[i] = v
+			// Fill the rest with the smallest values still unused
+			next := uint(0)
+			for j := i + 1; j < len(p); j++ {
+				for contains(p[:j], next) {
+					next++
+				}
+				p[j] = next
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// NextProduct steps t like an odometer to the next tuple of the Cartesian
+// product, where t[i] ranges over {0, 1, ..., sizes[i]-1}.  It returns false
+// (leaving t alone) if t was already the last one.
+func NextProduct(t []uint, sizes []uint) bool {
+	i := len(t) - 1
+	for ; i >= 0 && t[i] == sizes[i]-1; i-- {
+	}
+	if i < 0 {
+		return false
+	}
+	t[i]++
+	for j := i + 1; j < len(t); j++ {
+		t[j] = 0
+	}
+	return true
+}
+
+// Combinations yields every k-combination of {0, 1, ..., n-1} in
+// lexicographic order, the same as Python's itertools.combinations.
+func Combinations(n, k uint, mode BufferMode) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		if k > n {
+			return
+		}
+		c := iota_(k)
+		enumerate(c, mode, func() bool {
+			return NextCombination(c, n)
+		}, yield)
+	}
+}
+
+// CombinationsReplacement yields every k-combination with replacement of
+// {0, 1, ..., n-1} in lexicographic order.
+func CombinationsReplacement(n, k uint, mode BufferMode) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		if n == 0 && k > 0 {
+			return
+		}
+		c := make([]uint, k)
+		enumerate(c, mode, func() bool {
+			return NextCombinationReplacement(c, n)
+		}, yield)
+	}
+}
+
+// Permutations yields every permutation of {0, 1, ..., n-1} in
+// lexicographic order.
+func Permutations(n uint, mode BufferMode) iter.Seq[[]uint] {
+	return KPermutations(n, n, mode)
+}
+
+// KPermutations yields every k-permutation of {0, 1, ..., n-1} in
+// lexicographic order.
+func KPermutations(n, k uint, mode BufferMode) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		if k > n {
+			return
+		}
+		p := iota_(k)
+		next := func() bool { return NextKPermutation(p, n) }
+		// Full permutations have a much cheaper successor
+		if k == n {
+			next = func() bool { return NextPermutation(p) }
+		}
+		enumerate(p, mode, next, yield)
+	}
+}
+
+// Product yields every tuple of the Cartesian product of the index ranges
+// {0, ..., sizes[0]-1} x {0, ..., sizes[1]-1} x ... in lexicographic order.
+func Product(sizes []uint, mode BufferMode) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		if slices.Contains(sizes, 0) {
+			return
+		}
+		t := make([]uint, len(sizes))
+		enumerate(t, mode, func() bool {
+			return NextProduct(t, sizes)
+		}, yield)
+	}
+}
@@ -0,0 +1,132 @@
+package combinatorics
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+)
+
+// digits concatenates every item of seq, e.g. {0 1} {0 2} -> "0102".
+func digits(seq iter.Seq[[]uint]) string {
+	rv := ""
+	for item := range seq {
+		for _, d := range item {
+			rv += fmt.Sprint(d)
+		}
+	}
+	return rv
+}
+
+func TestIterators(t *testing.T) {
+	type Pair struct {
+		name     string
+		input    iter.Seq[[]uint]
+		expected string
+	}
+
+	// General form of the Python code to generate a strings to test against
+	// "".join("".join(str(n) for n in ns)
+	//     for ns in itertools.combinations(range(n), k))
+	pairs := []Pair{
+		{"Combinations(5, 3)", Combinations(5, 3, BUFFER_FRESH),
+			"012013014023024034123124134234"},
+		{"Combinations(3, 4)", Combinations(3, 4, BUFFER_FRESH), ""},
+		{"CombinationsReplacement(3, 2)",
+			CombinationsReplacement(3, 2, BUFFER_REUSE),
+			"000102111222"},
+		{"Permutations(3)", Permutations(3, BUFFER_REUSE),
+			"012021102120201210"},
+		{"KPermutations(4, 2)", KPermutations(4, 2, BUFFER_REUSE),
+			"010203101213202123303132"},
+		{"Product(2, 3)", Product([]uint{2, 3}, BUFFER_REUSE),
+			"000102101112"},
+		{"Product(2, 0)", Product([]uint{2, 0}, BUFFER_REUSE), ""},
+	}
+	for _, p := range pairs {
+		result := digits(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %v\nExpected: %#v\n     Got: %#v\n",
+				p.name, p.expected, result)
+		}
+	}
+}
+
+func TestIteratorsEmpty(t *testing.T) {
+	// Like itertools, there is exactly one way to choose nothing
+	for _, seq := range []iter.Seq[[]uint]{
+		Combinations(3, 0, BUFFER_FRESH),
+		CombinationsReplacement(0, 0, BUFFER_FRESH),
+		Permutations(0, BUFFER_FRESH),
+		Product(nil, BUFFER_FRESH),
+	} {
+		count := 0
+		for item := range seq {
+			if len(item) != 0 {
+				t.Fatalf("Expected an empty item, got %v", item)
+			}
+			count++
+		}
+		if count != 1 {
+			t.Fatalf("Expected 1 empty item, got %v", count)
+		}
+	}
+}
+
+func TestIteratorsCount(t *testing.T) {
+	count := uint64(0)
+	for range KPermutations(7, 4, BUFFER_REUSE) {
+		count++
+	}
+	expected, _ := Permutation(7, 4)
+	if count != expected {
+		t.Fatalf("Expected %v k-permutations, got %v", expected, count)
+	}
+}
+
+func TestBufferMode(t *testing.T) {
+	var fresh, reused [][]uint
+	for c := range Combinations(4, 2, BUFFER_FRESH) {
+		fresh = append(fresh, c)
+	}
+	for c := range Combinations(4, 2, BUFFER_REUSE) {
+		reused = append(reused, c)
+	}
+
+	// Fresh slices stay the way they were yielded
+	expected := "[[0 1] [0 2] [0 3] [1 2] [1 3] [2 3]]"
+	if result := fmt.Sprint(fresh); expected != result {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+	// Reused slices all share one backing array, left at the last combo
+	for _, c := range reused {
+		if &c[0] != &reused[0][0] {
+			t.Fatalf("Expected a reused buffer, got %p and %p",
+				c, reused[0])
+		}
+	}
+}
+
+func TestIteratorsBreak(t *testing.T) {
+	count := 0
+	for range Permutations(10, BUFFER_REUSE) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Fatalf("Expected to stop after 3, got %v", count)
+	}
+}
+
+func TestNextPermutationMultiset(t *testing.T) {
+	p := []uint{0, 0, 1}
+	result := fmt.Sprint(p)
+	for NextPermutation(p) {
+		result += fmt.Sprint(p)
+	}
+	expected := "[0 0 1][0 1 0][1 0 0]"
+	if expected != result {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+}
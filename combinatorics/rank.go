@@ -0,0 +1,300 @@
+package combinatorics
+
+import (
+	"errors"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+var (
+	// ErrRange is returned when unranking past the last item.
+	ErrRange = errors.New("combinatorics: rank out of range")
+	// ErrInvalid is returned when ranking something that isn't a valid
+	// combination or permutation.
+	ErrInvalid = errors.New(
+		"combinatorics: not a valid combination or permutation")
+)
+
+// add returns a + b, or ErrOverflow if the sum doesn't fit in a uint64.
+func add(a, b uint64) (uint64, error) {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+//
+// Combinations
+//
+
+// RankCombination returns the position of c among the k-combinations of
+// {0, 1, ..., n-1} in lexicographic order (the order Combinations yields
+// them in), where k is len(c).
+//
+// This uses the combinatorial number system.  Taking each element's
+// complement n-1-c[i] turns lexicographic order into reverse colexicographic
+// order, and the colex rank of a combo is the sum of C(c[i], i+1).
+func RankCombination(c []uint, n uint) (uint64, error) {
+	k := uint64(len(c))
+	for i, x := range c {
+		if x >= n || (i > 0 && x <= c[i-1]) {
+			return 0, ErrInvalid
+		}
+	}
+
+	total, err := Combination(uint64(n), k)
+	if err != nil {
+		return 0, err
+	}
+	colex := uint64(0)
+	for i, x := range c {
+		// The complements are in descending order, so the i'th one is
+		// the (k-i)'th smallest
+		term, err := Combination(uint64(n-1-x), k-uint64(i))
+		if err != nil {
+			return 0, err
+		}
+		// The colex rank is less than total, so this can't overflow
+		colex += term
+	}
+	return total - 1 - colex, nil
+}
+
+// UnrankCombination is the inverse of RankCombination.  It returns the
+// k-combination of {0, 1, ..., n-1} at position rank.
+func UnrankCombination(n, k uint, rank uint64) ([]uint, error) {
+	total, err := Combination(uint64(n), uint64(k))
+	if err != nil {
+		return nil, err
+	}
+	if rank >= total {
+		return nil, ErrRange
+	}
+
+	// Greedily peel off the largest C(x, r) that fits in the colex rank.
+	// x is a complement, so the combo comes out in ascending order.
+	colex := total - 1 - rank
+	c := make([]uint, k)
+	x := uint64(n)
+	for i := range c {
+		r := uint64(k) - uint64(i)
+		// C(x, r) grows with x, so binary search for the largest x
+		// less than the previous one with C(x, r) <= colex.  Nothing
+		// in this range can overflow, since every C(x, r) is at most
+		// C(n, k).
+		lo, hi := r-1, x-1
+		for lo < hi {
+			mid := hi - (hi-lo)/2
+			if term, _ := Combination(mid, r); term <= colex {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		x = lo
+		term, _ := Combination(x, r)
+		colex -= term
+		c[i] = n - 1 - uint(x)
+	}
+	return c, nil
+}
+
+// CombinationsFrom is Combinations, but starting at rank instead of at the
+// beginning.  Splitting the ranks up lets workers share an enumeration or
+// pick it back up after being interrupted.
+func CombinationsFrom(n, k uint, rank uint64, mode BufferMode) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		c, err := UnrankCombination(n, k, rank)
+		if err != nil {
+			return
+		}
+		enumerate(c, mode, func() bool {
+			return NextCombination(c, n)
+		}, yield)
+	}
+}
+
+//
+// Permutations
+//
+
+// RankPermutation returns the position of p among the permutations of
+// {0, 1, ..., len(p)-1} in lexicographic order.
+//
+// The rank is the Lehmer code of p (how many smaller elements come after
+// each element) read as a number in the factorial number system.  It is
+// built up with Horner's method, so only ranks that are too big overflow,
+// not the factorials.
+func RankPermutation(p []uint) (uint64, error) {
+	n := len(p)
+	seen := make([]bool, n)
+	for _, x := range p {
+		if x >= uint(n) || seen[x] {
+			return 0, ErrInvalid
+		}
+		seen[x] = true
+	}
+
+	rank := uint64(0)
+	for i, x := range p {
+		smaller := uint64(0)
+		for _, y := range p[i+1:] {
+			if y < x {
+				smaller++
+			}
+		}
+		var err error
+		if rank, err = mul(rank, uint64(n-i)); err != nil {
+			return 0, err
+		}
+		if rank, err = add(rank, smaller); err != nil {
+			return 0, err
+		}
+	}
+	return rank, nil
+}
+
+// UnrankPermutation is the inverse of RankPermutation.  It returns the
+// permutation of {0, 1, ..., n-1} at position rank.
+func UnrankPermutation(n uint, rank uint64) ([]uint, error) {
+	// Digits of the Lehmer code, least significant (radix 1) last
+	code := make([]uint, n)
+	for i := int(n) - 1; i >= 0; i-- {
+		radix := uint64(n) - uint64(i)
+		code[i] = uint(rank % radix)
+		rank /= radix
+	}
+	if rank != 0 {
+		return nil, ErrRange
+	}
+
+	remaining := iota_(n)
+	p := make([]uint, n)
+	for i, d := range code {
+		p[i] = remaining[d]
+		remaining = slices.Delete(remaining, int(d), int(d)+1)
+	}
+	return p, nil
+}
+
+// PermutationsFrom is Permutations, but starting at rank instead of at the
+// beginning.
+func PermutationsFrom(n uint, rank uint64, mode BufferMode) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		p, err := UnrankPermutation(n, rank)
+		if err != nil {
+			return
+		}
+		enumerate(p, mode, func() bool {
+			return NextPermutation(p)
+		}, yield)
+	}
+}
+
+//
+// Multiset permutations
+//
+
+// tally returns the distinct values of multiset in ascending order along
+// with how many times each one occurs.
+func tally(multiset []uint) (values []uint, counts []uint64) {
+	sorted := slices.Sorted(slices.Values(multiset))
+	for i, x := range sorted {
+		if i == 0 || x != sorted[i-1] {
+			values = append(values, x)
+			counts = append(counts, 0)
+		}
+		counts[len(counts)-1]++
+	}
+	return values, counts
+}
+
+// arrangements returns how many distinct ways the items can be ordered,
+// (sum counts)! / (counts[0]! counts[1]! ...), as a product of binomials.
+func arrangements(counts []uint64) (uint64, error) {
+	rv, total := uint64(1), uint64(0)
+	for _, count := range counts {
+		var err error
+		if total, err = add(total, count); err != nil {
+			return 0, err
+		}
+		ways, err := Combination(total, count)
+		if err != nil {
+			return 0, err
+		}
+		if rv, err = mul(rv, ways); err != nil {
+			return 0, err
+		}
+	}
+	return rv, nil
+}
+
+// arrangementsWithout is arrangements after using up one of counts[i].
+func arrangementsWithout(counts []uint64, i int) (uint64, error) {
+	counts[i]--
+	defer func() { counts[i]++ }()
+	return arrangements(counts)
+}
+
+// RankMultisetPermutation returns the position of p among the distinct
+// permutations of its own elements in lexicographic order.  Unlike
+// RankPermutation, values may repeat.
+func RankMultisetPermutation(p []uint) (uint64, error) {
+	values, counts := tally(p)
+
+	rank := uint64(0)
+	for _, x := range p {
+		// Every arrangement starting with a smaller value comes first
+		for i, v := range values {
+			if v == x {
+				counts[i]--
+				break
+			}
+			if counts[i] == 0 {
+				continue
+			}
+			ways, err := arrangementsWithout(counts, i)
+			if err != nil {
+				return 0, err
+			}
+			if rank, err = add(rank, ways); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return rank, nil
+}
+
+// UnrankMultisetPermutation is the inverse of RankMultisetPermutation.  It
+// returns the distinct permutation of the elements of multiset (in any
+// order) at position rank.
+func UnrankMultisetPermutation(multiset []uint, rank uint64) ([]uint, error) {
+	values, counts := tally(multiset)
+	total, err := arrangements(counts)
+	if err != nil {
+		return nil, err
+	}
+	if rank >= total {
+		return nil, ErrRange
+	}
+
+	p := make([]uint, 0, len(multiset))
+	for len(p) < len(multiset) {
+		for i, v := range values {
+			if counts[i] == 0 {
+				continue
+			}
+			// Fits in a uint64 because total did
+			ways, _ := arrangementsWithout(counts, i)
+			if rank < ways {
+				p = append(p, v)
+				counts[i]--
+				break
+			}
+			rank -= ways
+		}
+	}
+	return p, nil
+}
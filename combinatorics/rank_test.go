@@ -0,0 +1,160 @@
+package combinatorics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRankCombination(t *testing.T) {
+	// Every combo should round trip to its position in Combinations
+	for _, args := range [][2]uint{{7, 3}, {5, 0}, {5, 5}, {10, 1}} {
+		n, k := args[0], args[1]
+		i := uint64(0)
+		for c := range Combinations(n, k, BUFFER_REUSE) {
+			rank, err := RankCombination(c, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rank != i {
+				t.Fatalf("Input: %v of %v\nExpected: %v\n     Got: %v\n",
+					c, n, i, rank)
+			}
+			unranked, err := UnrankCombination(n, k, rank)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(c, unranked) {
+				t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+					rank, c, unranked)
+			}
+			i++
+		}
+	}
+}
+
+func TestUnrankCombinationLarge(t *testing.T) {
+	// C(60, 30) is close to the limit, so this checks that none of the
+	// intermediate binomials overflow
+	total, _ := Combination(60, 30)
+	for _, rank := range []uint64{0, 1, total / 3, total - 1} {
+		c, err := UnrankCombination(60, 30, rank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := RankCombination(c, 60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rank != result {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				c, rank, result)
+		}
+	}
+
+	if _, err := UnrankCombination(60, 30, total); err != ErrRange {
+		t.Fatalf("Expected ErrRange, got %v", err)
+	}
+	if _, err := RankCombination([]uint{1, 1}, 3); err != ErrInvalid {
+		t.Fatalf("Expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestCombinationsFrom(t *testing.T) {
+	// Two shards of the same enumeration should join back up into it
+	result := digits(CombinationsFrom(5, 3, 0, BUFFER_REUSE))[:3*4] +
+		digits(CombinationsFrom(5, 3, 4, BUFFER_REUSE))
+	expected := digits(Combinations(5, 3, BUFFER_REUSE))
+	if expected != result {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestRankPermutation(t *testing.T) {
+	i := uint64(0)
+	for p := range Permutations(5, BUFFER_REUSE) {
+		rank, err := RankPermutation(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rank != i {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				p, i, rank)
+		}
+		unranked, err := UnrankPermutation(5, rank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(p, unranked) {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				rank, p, unranked)
+		}
+		i++
+	}
+}
+
+func TestRankPermutationLarge(t *testing.T) {
+	// 25! doesn't fit, but the first few ranks still do
+	p, err := UnrankPermutation(25, 12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rank, err := RankPermutation(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rank != 12345 {
+		t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n", p, 12345, rank)
+	}
+
+	// The last permutation of 21 doesn't
+	last := iota_(21)
+	slices.Reverse(last)
+	if _, err := RankPermutation(last); err != ErrOverflow {
+		t.Fatalf("Expected ErrOverflow, got %v", err)
+	}
+	if _, err := UnrankPermutation(3, 6); err != ErrRange {
+		t.Fatalf("Expected ErrRange, got %v", err)
+	}
+	if _, err := RankPermutation([]uint{0, 2}); err != ErrInvalid {
+		t.Fatalf("Expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestRankMultisetPermutation(t *testing.T) {
+	// Walk the distinct permutations with NextPermutation, which already
+	// skips duplicates
+	p := []uint{1, 1, 2, 5, 5}
+	i := uint64(0)
+	for {
+		rank, err := RankMultisetPermutation(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rank != i {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				p, i, rank)
+		}
+		unranked, err := UnrankMultisetPermutation(
+			[]uint{5, 2, 1, 5, 1}, rank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(p, unranked) {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				rank, p, unranked)
+		}
+		i++
+		if !NextPermutation(p) {
+			break
+		}
+	}
+
+	// 5! / (2! 1! 2!)
+	if i != 30 {
+		t.Fatalf("Expected 30 permutations, got %v", i)
+	}
+	_, err := UnrankMultisetPermutation([]uint{1, 1, 2, 5, 5}, 30)
+	if err != ErrRange {
+		t.Fatalf("Expected ErrRange, got %v", err)
+	}
+}
@@ -10,8 +10,9 @@ import (
 
 // Imports for the specific problem
 import (
-	"strconv"
 	"strings"
+
+	"github.com/carbonizer/codeeval-go/sliceutil"
 )
 
 //
@@ -114,21 +115,16 @@ func main() {
 func DataRecovery(input []byte) (interface{}, error) {
 	lines := strings.Split(strings.TrimRight(string(input), "\n"), "\n")
 	recoveredLines := make([]string, len(lines))
-	err := error(nil)
 
 	for i, line := range lines {
 		// Semicolon splits mixed words and positions
 		semicolonSplits := strings.Split(line, ";")
 		words := strings.Split(semicolonSplits[0], " ")
-		positionStrs := strings.Split(semicolonSplits[1], " ")
 
 		// Convert positions from strings to ints
-		positions := make([]int, len(positionStrs))
-		for j, posStr := range positionStrs {
-			positions[j], err = strconv.Atoi(posStr)
-			if err != nil {
-				log.Fatal("Couldn't convert %#v", posStr)
-			}
+		positions, err := sliceutil.Fields[int](semicolonSplits[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
 		}
 
 		// pos is the 1-based position where words[j] needs to be moved
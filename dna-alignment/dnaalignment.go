@@ -10,9 +10,11 @@ import (
 
 // Imports for the specific problem
 import (
-	"errors"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/carbonizer/codeeval-go/combinatorics"
 )
 
 //
@@ -112,67 +114,6 @@ func main() {
 	inputToFuncToStdout(DnaAlignment, INPUT_CONSTANT)
 }
 
-// StrsToInts converts a slice of strings to a slice of ints.
-func StrsToInts(strs []string) ([]int, error) {
-	ints := make([]int, len(strs))
-	for i, str := range strs {
-		int_, err := strconv.Atoi(str)
-		if err != nil {
-			return ints, err
-		}
-		ints[i] = int_
-	}
-	return ints, nil
-}
-
-// IntsToStrs converts a slice of ints to a slice of strings.
-func IntsToStrs(ints []int) []string {
-	strs := make([]string, len(ints))
-	for i, int_ := range ints {
-		str := strconv.Itoa(int_)
-		strs[i] = str
-	}
-	return strs
-}
-
-// UIntsToStrs converts a slice of ints to a slice of strings.
-func UIntsToStrs(uints []uint) []string {
-	strs := make([]string, len(uints))
-	for i, uint_ := range uints {
-		str := strconv.FormatUint(uint64(uint_), 10)
-		strs[i] = str
-	}
-	return strs
-}
-
-// Factorial calculates the product of (n, n-1, n-2, ..., 3, 2).
-func Factorial(n uint) uint {
-	var rv uint
-	for rv = 1; n > 1; n-- {
-		rv *= n
-	}
-	return rv
-}
-
-// Combination calculates the number of combos when choosing k elems from n.
-// There is NO replacement, and the order of the elements doesn't matter (the
-// same elements in a different order is considered the combination
-func Combination(n, k uint) uint {
-	return Factorial(n) / (Factorial(k) * Factorial(n-k))
-}
-
-// CombinationReplacement calculates combination with replacement.
-func CombinationReplacement(n, k uint) uint {
-	return Combination(n+k-1, k)
-}
-
-// Permutation calculates the number of ways k elems can be selected from n.
-// There is NO replacement, but order is important (the same elements in a
-// different order is a different permutation
-func Permutation(n, k uint) uint {
-	return Factorial(n) / (Factorial(n - k))
-}
-
 // MakeRange returns such that `CountingNumbers[s:e] == MakeRange(s:e)`.
 // CountingNumbers are {0, 1, 2, ...}
 func MakeRange(start, end uint) []uint {
@@ -189,119 +130,20 @@ func MakeRange(start, end uint) []uint {
 	return rv
 }
 
-// IndexCombinationsReplacement returns all combinations of indices.
+// IndexCombinations returns all combinations of indices.
 // k elements are selected from {0, 1, ..., n-1}.  Theses can then be used to
-// to create combinations of other slices.
+// to create combinations of other slices.  Every combo has its own backing
+// array.  Prefer combinatorics.Combinations when the combos don't all need to
+// be in memory at once.
 func IndexCombinations(n, k uint) [][]uint {
-	combos := make([][]uint, Combination(n, k))
-	switch k {
-	case 0:
-		// Nothing.  Init value will work
-	case 1:
-		// [[0] [1] ... [n-1]]
-		for i, _ := range combos {
-			combos[i] = []uint{uint(i)}
-		}
-	default:
-		// Get the preliminary k-1 element groups.  Because of the way
-		// the combos are constructed, they are already sorted. Also,
-		// because there is NO replacement, the first k-1 elements
-		// can never include the greatest index.  As such, the first
-		// argument to the recursive call is n-1
-		missingLasts := IndexCombinations(n-1, k-1)
-
-		// For each k-1 group, append possible k'th values, each
-		// creating a separate, new combo
-		i := 0
-		for _, missingLast := range missingLasts {
-			// Since there is NO replacement, the last element must
-			// have a value greater than the (k-1)'th element
-			start := missingLast[len(missingLast)-1] + 1
-
-			for _, last := range MakeRange(start, n) {
-				combos[i] = append(missingLast, last)
-				// Inner iteration varies in size so we need to
-				// increment the index manually
-				i++
-			}
-		}
-	}
-	return combos
+	return slices.Collect(combinatorics.Combinations(
+		n, k, combinatorics.BUFFER_FRESH))
 }
 
 // IndexCombinationsReplacement returns all combos with replacement of indices.
 func IndexCombinationsReplacement(n, k uint) [][]uint {
-	combos := make([][]uint, CombinationReplacement(n, k))
-	switch k {
-	case 0:
-		// Nothing.  Init value will work
-	case 1:
-		// [[0] [1] ... [n-1]]
-		for i, _ := range combos {
-			combos[i] = []uint{uint(i)}
-		}
-	default:
-		// Get the preliminary k-1 element groups
-		missingLasts := IndexCombinationsReplacement(n, k-1)
-
-		// For each k-1 group, append possible k'th values, each
-		// creating a separate, new combo
-		i := 0
-		for _, missingLast := range missingLasts {
-			// Due to the nature of how these combos are built up,
-			// they are already sorted.  As such, the last value
-			// must be greater than or equal the last element.
-			// Equal to is included because replacement is allowed.
-			start := missingLast[len(missingLast)-1]
-
-			for _, last := range MakeRange(start, n) {
-				combos[i] = append(missingLast, last)
-				// Inner iteration varies in size so we need to
-				// increment the index manually
-				i++
-			}
-		}
-	}
-	return combos
-}
-
-// Concat concatenates each slice of a slice of slices into a single slice.
-func Concat(a interface{}) (interface{}, error) {
-	switch outer := a.(type) {
-	case [][]int:
-		outs := []int{}
-		for _, inner := range outer {
-			outs = append(outs, inner...)
-		}
-		return outs, nil
-	case [][]uint:
-		outs := []uint{}
-		for _, inner := range outer {
-			outs = append(outs, inner...)
-		}
-		return outs, nil
-	case [][]byte:
-		outs := []byte{}
-		for _, inner := range outer {
-			outs = append(outs, inner...)
-		}
-		return outs, nil
-	case [][]rune:
-		outs := []rune{}
-		for _, inner := range outer {
-			outs = append(outs, inner...)
-		}
-		return outs, nil
-	case [][]string:
-		outs := []string{}
-		for _, inner := range outer {
-			outs = append(outs, inner...)
-		}
-		return outs, nil
-	default:
-		return nil, errors.New("Input type must be one of: " +
-			"[][]int, [][]uint, [][]byte, [][]rune, [][]string")
-	}
+	return slices.Collect(combinatorics.CombinationsReplacement(
+		n, k, combinatorics.BUFFER_FRESH))
 }
 
 // How to score attempt by comparing corresponding runes
@@ -367,16 +209,19 @@ func DnaAlignment(input []byte) (interface{}, error) {
 		// twice. Note that because we are ignoring the first rune,
 		// these indices will be shifted from what they need to be.
 		// This is compensated for later, when they are used.
-		replaceCombos := IndexCombinations(
-			uint(len(full)-2), uint(len(partial)-2))
+		//
+		// The combos are generated one at a time into the same buffer,
+		// so memory doesn't grow with how many of them there are.
+		replaceCombos := combinatorics.Combinations(
+			uint(len(full)-2), uint(len(partial)-2),
+			combinatorics.BUFFER_REUSE)
 
-		possibles := make([]Attempt, len(replaceCombos))
 		// I originally used MinInt, but it isn't part of the std lib,
 		// so it didn't work as a solution. -10000 should be more than
 		// enough anyway.
 		best := Attempt{[]rune{}, -10000}
 
-		for j, combo := range replaceCombos {
+		for combo := range replaceCombos {
 			// Prep output with default values
 			out := make([]rune, len(full))
 			for k, _ := range out {
@@ -411,7 +256,6 @@ func DnaAlignment(input []byte) (interface{}, error) {
 			if poss.score > best.score {
 				best = poss
 			}
-			possibles[j] = poss
 		}
 
 		outLines[i] = strconv.Itoa(best.score)
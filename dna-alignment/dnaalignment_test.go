@@ -2,24 +2,10 @@ package main
 
 import (
 	"fmt"
-	"strings"
 	"testing"
-)
-
-func TestFactorial(t *testing.T) {
-	type Pair struct {
-		input, expected uint
-	}
 
-	pairs := []Pair{{0, 1}, {1, 1}, {2, 2}, {3, 6}, {7, 5040}}
-	for _, p := range pairs {
-		result := Factorial(p.input)
-		if p.expected != result {
-			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
-				p.input, p.expected, result)
-		}
-	}
-}
+	"github.com/carbonizer/codeeval-go/sliceutil"
+)
 
 func TestMakeRange(t *testing.T) {
 	type Args struct {
@@ -98,8 +84,7 @@ func TestIndexCombinations2(t *testing.T) {
 	}
 	for _, p := range pairs {
 		rv := IndexCombinations(p.args.n, p.args.k)
-		rv2, _ := Concat(rv)
-		result := strings.Join(UIntsToStrs(rv2.([]uint)), "")
+		result := sliceutil.Join(sliceutil.Concat(rv), "")
 		if fmt.Sprintf("%#v", p.expected) !=
 			fmt.Sprintf("%#v", result) {
 			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
@@ -140,9 +125,8 @@ func TestIndexCombinationsReplacement(t *testing.T) {
 			result = fmt.Sprintf("%#v", rv)
 		} else if p.expectedString != "" {
 			expected = fmt.Sprintf("%#v", p.expectedString)
-			rv2, _ := Concat(rv)
-			result = fmt.Sprintf("%#v", strings.Join(
-				UIntsToStrs(rv2.([]uint)), ""))
+			result = fmt.Sprintf("%#v",
+				sliceutil.Join(sliceutil.Concat(rv), ""))
 		}
 
 		if expected != result {
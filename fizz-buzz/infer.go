@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/carbonizer/codeeval-go/primes"
+)
+
+// InconsistentError says no DivisibleBy rules could have produced a
+// sequence, and where it first stops being possible.
+type InconsistentError struct {
+	// Pos is the 1-based position of the token that contradicts the ones
+	// before it
+	Pos   int
+	Token string
+}
+
+func (e *InconsistentError) Error() string {
+	return fmt.Sprintf("inconsistent at position %v: %#v", e.Pos, e.Token)
+}
+
+// splitLabels finds the labels behind the label tokens, which are each
+// some of the labels joined in order.  Starting from the tokens themselves,
+// it keeps splitting pieces that other pieces show are joined:
+//
+//   - "FizzBuzz" is "Fizz" and "Buzz" once "Buzz" is a piece
+//   - "FizzBazz" is "Fizz" and "Bazz" when "FizzBuzzBazz" is a piece, and
+//     "FizzBuzzBazz" is split next
+//
+// A label found inside another is taken to be part of it.  It returns the
+// labels in the order they first appear, and each token split into them.
+func splitLabels(tokens []string) (labels []string, parts map[string][]string) {
+	parts = map[string][]string{}
+	for _, token := range tokens {
+		parts[token] = []string{token}
+	}
+	for {
+		pieces := []string{}
+		for _, token := range tokens {
+			pieces = append(pieces, parts[token]...)
+		}
+		slices.Sort(pieces)
+		pieces = slices.Compact(pieces)
+
+		var piece string
+		var split []string
+		for _, a := range pieces {
+			for _, b := range pieces {
+				if split = splitBy(a, b); split != nil {
+					piece = a
+					break
+				}
+			}
+			if split != nil {
+				break
+			}
+		}
+		if split == nil {
+			break
+		}
+		for token, ps := range parts {
+			for i := 0; i < len(ps); i++ {
+				if ps[i] == piece {
+					ps = slices.Replace(ps, i, i+1, split...)
+					i += len(split) - 1
+				}
+			}
+			parts[token] = ps
+		}
+	}
+
+	for _, token := range tokens {
+		for _, piece := range parts[token] {
+			if !slices.Contains(labels, piece) {
+				labels = append(labels, piece)
+			}
+		}
+	}
+	return labels, parts
+}
+
+// splitBy returns how piece a splits because of piece b, or nil if it
+// doesn't.  a splits around b if b is inside it, and into the ends of b if
+// b is a with something more in the middle.  Splits before a capital are
+// tried first.
+func splitBy(a, b string) []string {
+	if len(b) >= len(a) {
+		// If b holds a whole, it's b that splits
+		if strings.Contains(b, a) {
+			return nil
+		}
+		for _, k := range splitPoints(a) {
+			if strings.HasPrefix(b, a[:k]) && strings.HasSuffix(b, a[k:]) {
+				return []string{a[:k], a[k:]}
+			}
+		}
+		return nil
+	}
+	i := strings.Index(a, b)
+	if i < 0 {
+		return nil
+	}
+	split := []string{}
+	for _, piece := range []string{a[:i], b, a[i+len(b):]} {
+		if len(piece) > 0 {
+			split = append(split, piece)
+		}
+	}
+	return split
+}
+
+// splitPoints lists the places s could be split, before capitals first.
+func splitPoints(s string) []int {
+	upper, rest := []int{}, []int{}
+	for k, r := range s {
+		switch {
+		case k == 0:
+		case unicode.IsUpper(r):
+			upper = append(upper, k)
+		default:
+			rest = append(rest, k)
+		}
+	}
+	return append(upper, rest...)
+}
+
+// smallestDivisor returns the smallest d that divides every number in
+// hits and none in misses, and false if there isn't one.
+func smallestDivisor(hits, misses []int_) (int_, bool) {
+	g := uint64(0)
+	for _, n := range hits {
+		g = primes.GCD(g, uint64(max(n, -n)))
+	}
+	fits := func(d int_) bool {
+		for _, n := range misses {
+			if n%d == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if g == 0 {
+		// Only 0 has the label, so anything bigger than the misses works
+		top := int_(0)
+		for _, n := range misses {
+			top = max(top, n, -n)
+		}
+		for d := int_(1); d <= top+1; d++ {
+			if fits(d) {
+				return d, true
+			}
+		}
+	}
+	for _, d := range primes.Divisors(g) {
+		if fits(int_(d)) {
+			return int_(d), true
+		}
+	}
+	return 0, false
+}
+
+// INFER_START_MAX is the furthest inferRules looks for where a sequence
+// with no numbers in it starts.
+const INFER_START_MAX = 1 << 12
+
+// inferRules finds the smallest divisors for DivisibleBy rules that produce
+// tokens, along with the number the tokens start from.  Any numbers among
+// the tokens fix where it starts, and otherwise it's the first from 1 up
+// that works.
+func inferRules(tokens []string) (rs Rules, first int_, err error) {
+	labelTokens := []string{}
+	foundNum := false
+	for i, token := range tokens {
+		num, err := strconv.Atoi(token)
+		if err != nil {
+			labelTokens = append(labelTokens, token)
+		} else if !foundNum {
+			first, foundNum = int_(num-i), true
+		}
+	}
+	labels, parts := splitLabels(labelTokens)
+	// Each rule adds its label once
+	for token, ps := range parts {
+		sorted := slices.Sorted(slices.Values(ps))
+		if len(slices.Compact(sorted)) < len(ps) {
+			return nil, 0, fmt.Errorf("a label twice in %#v", token)
+		}
+	}
+	if foundNum {
+		rs, err = inferFrom(tokens, first, labels, parts)
+		return rs, first, err
+	}
+	for first = 1; first <= INFER_START_MAX; first++ {
+		if rs, err = inferFrom(tokens, first, labels, parts); err == nil {
+			break
+		}
+	}
+	return rs, first, err
+}
+
+// inferFrom is inferRules for tokens starting from first, with their labels
+// already split out.
+func inferFrom(tokens []string, first int_, labels []string,
+	parts map[string][]string) (rs Rules, err error) {
+	labels = slices.Clone(labels)
+
+	// Which numbers have each label, and which must come before which
+	hits := map[string][]int_{}
+	before := map[string]map[string]bool{}
+	for _, label := range labels {
+		before[label] = map[string]bool{}
+	}
+	for i, token := range tokens {
+		num := first + int_(i)
+		for j, label := range parts[token] {
+			hits[label] = append(hits[label], num)
+			for _, later := range parts[token][j+1:] {
+				before[label][later] = true
+			}
+		}
+	}
+
+	divisors := map[string]int_{}
+	for _, label := range labels {
+		misses := []int_{}
+		for i, token := range tokens {
+			if !slices.Contains(parts[token], label) {
+				misses = append(misses, first+int_(i))
+			}
+		}
+		d, ok := smallestDivisor(hits[label], misses)
+		if !ok {
+			return nil, fmt.Errorf("no divisor for %#v", label)
+		}
+		divisors[label] = d
+	}
+
+	// Smallest divisor first, as long as the joined labels allow it
+	sort.SliceStable(labels, func(i, j int) bool {
+		return divisors[labels[i]] < divisors[labels[j]]
+	})
+	for len(labels) > 0 {
+		next := slices.IndexFunc(labels, func(label string) bool {
+			for _, other := range labels {
+				if before[other][label] {
+					return false
+				}
+			}
+			return true
+		})
+		if next < 0 {
+			return nil, fmt.Errorf("labels joined in different orders")
+		}
+		label := labels[next]
+		rs = rs.Add(DivisibleBy{labeled{label}, divisors[label]})
+		labels = slices.Delete(labels, next, next+1)
+	}
+
+	// Labels can split more than one way, so make sure these rules really
+	// do give back the tokens
+	for i, token := range tokens {
+		if rs.Apply(first+int_(i)) != token {
+			return nil, fmt.Errorf("rules don't give back %#v", token)
+		}
+	}
+	return rs, nil
+}
+
+// Infer finds the rules behind a sequence of Fizz Buzz output, or an
+// InconsistentError if there aren't any.  Its answer, like
+// "3:F 5:B | 1..10", is input that gives the sequence back.
+func Infer(tokens []string) (string, error) {
+	rs, first, err := inferRules(tokens)
+	if err != nil {
+		// A prefix that works still works with fewer tokens, so the
+		// first that doesn't can be found by bisecting
+		n := sort.Search(len(tokens), func(n int) bool {
+			_, _, err := inferRules(tokens[:n+1])
+			return err != nil
+		})
+		return "", &InconsistentError{n + 1, tokens[n]}
+	}
+
+	ruleStrs := []string{}
+	for _, r := range rs {
+		ruleStrs = append(ruleStrs, fmt.Sprintf("%v:%v",
+			r.Rule.(DivisibleBy).Divisor, r.Label()))
+	}
+	last := first + int_(len(tokens)) - 1
+	return strings.TrimSpace(fmt.Sprintf("%v | %v..%v",
+		strings.Join(ruleStrs, " "), first, last)), nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"1 2 F 4 B F 7 8 F B", "3:F 5:B | 1..10"},
+		{"1 F 3 F 5 F B F 9 F 11 F 13 FB 15", "2:F 7:B | 1..15"},
+		// Fizz and Buzz only ever appear together, so they are one label
+		{"1 2 FizzBuzz 4", "3:FizzBuzz | 1..4"},
+		{"Buzz 11 Fizz 13", "3:Fizz 5:Buzz | 10..13"},
+		// Nothing fixes where it starts, so it starts at 1
+		{"F F", "1:F | 1..2"},
+		{"1 2 3", "| 1..3"},
+		{"", "| 1..0"},
+		{"-1 Z 1", "2:Z | -1..1"},
+		// The smallest divisor, not the first that comes to mind
+		{"1 2 3 4 5 6 7 X", "8:X | 1..8"},
+		{"6 7 X 9 10 11 X", "4:X | 6..12"},
+	}
+	for _, p := range pairs {
+		result, err := Infer(strings.Fields(p.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	// Where it stops being possible
+	type Bad struct {
+		input string
+		pos   int
+		token string
+	}
+
+	bads := []Bad{{"1 2 F 4 B F 7 8 9 B", 9, "9"},
+		{"1 2 3 5", 4, "5"},
+		{"1 F 3 F 5 F 7 8", 8, "8"},
+		{"X 2 X", 2, "2"},
+		{"1 X X", 3, "X"},
+		{"1 AB 3 BA", 4, "BA"},
+		{"Z 0", 2, "0"}}
+	for _, b := range bads {
+		_, err := Infer(strings.Fields(b.input))
+		var inconsistent *InconsistentError
+		if !errors.As(err, &inconsistent) || inconsistent.Pos != b.pos ||
+			inconsistent.Token != b.token {
+			t.Fatalf("Input: %#v\nExpected: position %v %#v\n     Got: %v",
+				b.input, b.pos, b.token, err)
+		}
+	}
+
+	// Whatever comes out gives the sequence back
+	for i := 0; i < 200; i++ {
+		rules := Rules{}
+		for _, label := range []string{"Fizz", "Buzz", "Bazz"}[:rand.Intn(3)+1] {
+			rules = rules.Add(DivisibleBy{labeled{label},
+				int_(rand.Intn(12) + 1)})
+		}
+		first := int_(rand.Intn(100) + 1)
+		sb := &strings.Builder{}
+		writeFizzBuzz(sb, rules, first, first+int_(rand.Intn(60)))
+		sequence := sb.String()
+
+		inferred, err := Infer(strings.Fields(sequence))
+		if err != nil {
+			t.Fatalf("Input: %#v\n%v", sequence, err)
+		}
+		result, err := fizzBuzzLine(inferred)
+		if err != nil || sequence != result {
+			t.Fatalf("Input: %#v\nInferred: %#v\n     Got: %#v, %v\n",
+				sequence, inferred, result, err)
+		}
+	}
+}
+
+func TestInferLine(t *testing.T) {
+	input := "infer 1 2 F 4 B F 7 8 F B\n3 5 4\ninfer 1 1\n"
+	expected := "3:F 5:B | 1..10\n1 2 F 4\ninconsistent at position 2: \"1\""
+	result, err := fizzBuzz([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected != result {
+		t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+			input, expected, result)
+	}
+}
@@ -1,85 +1,118 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 )
 
 import (
-	"strconv"
-	"strings"
 	"log"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/sliceutil"
 )
 
 // Aliasing the type and implementing another interface wasn't a good idea in
 // this case, but I wanted to get some practice with it
 type int_ int
 
+// Dividend is anything that can be tested for divisibility, as int_ is.
 type Dividend interface {
-	IsDivisible(int) bool
+	IsDivisible(int_) bool
 }
 
+var _ Dividend = int_(0)
+
 // IsDivisible returns true if the dividend is evenly divisible by the divisor.
 func (dividend int_) IsDivisible(divisor int_) bool {
-	return dividend % divisor == 0
+	return dividend%divisor == 0
 }
 
-// Input parameters for each Fizz Buzz
-type params struct {
-	X, Y, Last int_
+// legacyRules are the rules for the original "X Y N" input, F for
+// multiples of X and B for multiples of Y.
+func legacyRules(x, y int_) Rules {
+	return Rules{}.Add(DivisibleBy{labeled{"F"}, x}).
+		Add(DivisibleBy{labeled{"B"}, y})
 }
 
-//
-func strToInts(input string) []int {
-	argStrs := strings.Split(input, " ")
-	args := make([]int, len(argStrs))
-	for j, str := range argStrs {
-		args[j], _ = strconv.Atoi(str)
+// parseLine parses one line of input, either the original "X Y N" or a
+// list of rules and a range like "3:Fizz 5:Buzz 7:Bazz | 1..100" (see
+// parseRules).
+func parseLine(line string) (rules Rules, first, last int_, err error) {
+	if strings.Contains(line, "|") {
+		return parseRules(line)
 	}
-	return args
-}
-
-// fizzBuzzNum performs Fizz Buzz on one number.
-func fizzBuzzNum(p *params, num int_) string {
-	str := ""
-	if num.IsDivisible(p.X) {
-		str += "F"
-	}
-	if num.IsDivisible(p.Y) {
-		str += "B"
+	args, err := sliceutil.Fields[int_](line)
+	if err != nil {
+		return nil, 0, 0, err
 	}
-	if len(str) == 0 {
-		str += strconv.Itoa(int(num))
+	if len(args) != 3 {
+		return nil, 0, 0, fmt.Errorf("expected X Y N, got %#v", line)
 	}
-	return str
+	return legacyRules(args[0], args[1]), 1, args[2], nil
 }
 
 // fizzBuzzLine performs Fizz Buzz for one line of input
-func fizzBuzzLine(line string) string {
-	args := strToInts(line)
-	p := params{int_(args[0]), int_(args[1]), int_(args[2])}
-	numStrs := make([]string, p.Last)
-
-	// For each num
-	for i, num := 0, int_(1); num <= p.Last; i, num = i + 1, num + 1 {
-		numStrs[i] = fizzBuzzNum(&p, num)
+func fizzBuzzLine(line string) (string, error) {
+	rules, first, last, err := parseLine(line)
+	if err != nil {
+		return "", err
+	}
+	sb := &strings.Builder{}
+	if err := writeFizzBuzz(sb, rules, first, last); err != nil {
+		return "", err
 	}
-	return strings.Join(numStrs, " ")
+	return sb.String(), nil
 }
 
-func fizzBuzz(stdin []byte) (interface{}, error) {
+// streamFizzBuzz performs Fizz Buzz for each line of stdin, writing each
+// answer to w as soon as it's parsed.  A line like "infer 1 2 F 4 B"
+// answers with the rules behind the sequence instead (see Infer).  Lines
+// before a bad one are already written when it returns the error.
+func streamFizzBuzz(stdin []byte, w io.Writer) error {
 	lines := strings.Split(strings.Trim(string(stdin), "\n"), "\n")
-	outs := make([]string, len(lines))
-	for i, line := range lines {
-		outs[i] = fizzBuzzLine(line)
+	for _, line := range lines {
+		// "infer <tokens>" goes the other way, and an inconsistent
+		// sequence is an answer rather than an error
+		if tokens := strings.Fields(line); len(tokens) > 0 &&
+			tokens[0] == "infer" {
+			answer, err := Infer(tokens[1:])
+			if err != nil {
+				answer = err.Error()
+			}
+			if _, err := io.WriteString(w, answer+"\n"); err != nil {
+				return err
+			}
+			continue
+		}
+		rules, first, last, err := parseLine(line)
+		if err != nil {
+			return err
+		}
+		if err := writeFizzBuzz(w, rules, first, last); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
 	}
-	rv := strings.Join(outs, "\n")
-	return rv, nil
+	return nil
 }
 
+func fizzBuzz(stdin []byte) (interface{}, error) {
+	sb := &strings.Builder{}
+	if err := streamFizzBuzz(stdin, sb); err != nil {
+		return nil, err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
 
-func stdinToFuncToStdout(fn func([]byte) (interface{}, error)) {
+// stdinToStreamToStdout is stdinToFuncToStdout for an fn that writes its
+// answer as it goes, through a buffer.
+func stdinToStreamToStdout(fn func([]byte, io.Writer) error) {
 	file, err := os.Open(os.Args[1])
 	if err != nil {
 		log.Fatal(err)
@@ -91,17 +124,16 @@ func stdinToFuncToStdout(fn func([]byte) (interface{}, error)) {
 		return
 	}
 
-	// Get call fn with stdin
-	rv, err := fn(stdin)
+	out := bufio.NewWriterSize(os.Stdout, STREAM_BUFFER_SIZE)
+	err = fn(stdin, out)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
 	if err != nil {
 		fmt.Println(err)
-		return
 	}
-
-	// Print result to stdout
-	fmt.Println(rv)
 }
 
 func main() {
-	stdinToFuncToStdout(fizzBuzz)
+	stdinToStreamToStdout(streamFizzBuzz)
 }
@@ -1,12 +1,11 @@
 package main
 
 import (
-	"testing"
 	"fmt"
 	"strings"
+	"testing"
 )
 
-
 func TestFizzBuzzLine(t *testing.T) {
 	type Pair struct {
 		input, expected string
@@ -17,7 +16,10 @@ func TestFizzBuzzLine(t *testing.T) {
 		{"2 7 15", "1 F 3 F 5 F B F 9 F 11 F 13 FB 15"},
 	}
 	for _, p := range pairs {
-		result := fizzBuzzLine(p.input)
+		result, err := fizzBuzzLine(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
 		if p.expected != result {
 			fmt.Println("Input:", p.input)
 			t.Fatalf("Expected %#v, got %#v", p.expected, result)
@@ -44,4 +46,3 @@ func TestFizzBuzz(t *testing.T) {
 		}
 	}
 }
-
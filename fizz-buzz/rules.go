@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/primes"
+)
+
+// Rule picks out the numbers that get a label instead of being written out.
+type Rule interface {
+	Matches(num int_) bool
+	Label() string
+}
+
+// labeled gives the built-in rules their Label method.
+type labeled struct {
+	Text string
+}
+
+func (l labeled) Label() string {
+	return l.Text
+}
+
+// DivisibleBy matches multiples of Divisor, like 3 for Fizz.
+type DivisibleBy struct {
+	labeled
+	Divisor int_
+}
+
+func (r DivisibleBy) Matches(num int_) bool {
+	return num.IsDivisible(r.Divisor)
+}
+
+// ContainsDigit matches numbers with Digit somewhere in them, in base 10.
+type ContainsDigit struct {
+	labeled
+	Digit int
+}
+
+func (r ContainsDigit) Matches(num int_) bool {
+	digit := strconv.Itoa(r.Digit)
+	return strings.Contains(strconv.Itoa(int(num)), digit)
+}
+
+// IsPrime matches prime numbers.
+type IsPrime struct {
+	labeled
+}
+
+func (r IsPrime) Matches(num int_) bool {
+	return num > 0 && primes.IsPrime(uint64(num))
+}
+
+// IsSquare matches perfect squares, 0 included.
+type IsSquare struct {
+	labeled
+}
+
+func (r IsSquare) Matches(num int_) bool {
+	if num < 0 {
+		return false
+	}
+	root := int_(math.Sqrt(float64(num)))
+	// The float can be off by one for large num, and squaring could
+	// overflow, so compare by dividing
+	for root > 0 && root > num/root {
+		root--
+	}
+	for root+1 <= num/(root+1) {
+		root++
+	}
+	return root*root == num
+}
+
+// Custom matches whatever Pred says to, for rules the input syntax can't
+// express.
+type Custom struct {
+	labeled
+	Pred func(int_) bool
+}
+
+func (r Custom) Matches(num int_) bool {
+	return r.Pred(num)
+}
+
+// ruleEntry is a Rule along with how its label combines with the others.
+type ruleEntry struct {
+	Rule
+	// An override's label replaces all the others instead of adding to
+	// them
+	override bool
+}
+
+// Rules are applied in order.  The labels of every matching rule are joined
+// in that order, unless an override matches, in which case the first such
+// override's label is used alone.
+type Rules []ruleEntry
+
+// Add appends a rule whose label joins the others.
+func (rs Rules) Add(r Rule) Rules {
+	return append(rs, ruleEntry{r, false})
+}
+
+// Override appends a rule whose label replaces the others.
+func (rs Rules) Override(r Rule) Rules {
+	return append(rs, ruleEntry{r, true})
+}
+
+// Apply returns the label for num, or num itself if no rule matches.
+func (rs Rules) Apply(num int_) string {
+	str := rs.label(num)
+	if len(str) == 0 {
+		str = strconv.Itoa(int(num))
+	}
+	return str
+}
+
+// label returns the label for num, or "" if no rule matches.
+func (rs Rules) label(num int_) string {
+	str := ""
+	for _, r := range rs {
+		if !r.Matches(num) {
+			continue
+		}
+		if r.override {
+			return r.Label()
+		}
+		str += r.Label()
+	}
+	return str
+}
+
+// parseRule parses one rule, "spec:Label" to add the label or "spec=Label"
+// to override.  spec is a divisor like "3", "has7" for numbers containing
+// the digit 7, "prime" or "square".
+func parseRule(rs Rules, field string) (Rules, error) {
+	i := strings.IndexAny(field, ":=")
+	if i <= 0 || i == len(field)-1 {
+		return nil, fmt.Errorf("expected spec:Label or spec=Label, got %#v",
+			field)
+	}
+	spec, l := field[:i], labeled{field[i+1:]}
+
+	var r Rule
+	switch {
+	case spec == "prime":
+		r = IsPrime{l}
+	case spec == "square":
+		r = IsSquare{l}
+	case strings.HasPrefix(spec, "has"):
+		digit, err := strconv.Atoi(spec[len("has"):])
+		if err != nil || digit < 0 || digit > 9 {
+			return nil, fmt.Errorf("expected a digit in %#v", field)
+		}
+		r = ContainsDigit{l, digit}
+	default:
+		divisor, err := strconv.Atoi(spec)
+		if err != nil || divisor <= 0 {
+			return nil, fmt.Errorf("expected a positive divisor in %#v",
+				field)
+		}
+		r = DivisibleBy{l, int_(divisor)}
+	}
+
+	if field[i] == '=' {
+		return rs.Override(r), nil
+	}
+	return rs.Add(r), nil
+}
+
+// parseRange parses "A..B", or "N" for 1..N.
+func parseRange(field string) (first, last int_, err error) {
+	lo, hi, found := strings.Cut(field, "..")
+	if !found {
+		lo, hi = "1", field
+	}
+	a, errA := strconv.Atoi(lo)
+	b, errB := strconv.Atoi(hi)
+	if errA != nil || errB != nil {
+		return 0, 0, fmt.Errorf("expected A..B or N, got %#v", field)
+	}
+	return int_(a), int_(b), nil
+}
+
+// parseRules parses a line like "3:Fizz 5:Buzz 7:Bazz | 1..100" into its
+// rules and the range to apply them to.
+func parseRules(line string) (rs Rules, first, last int_, err error) {
+	ruleStr, rangeStr, _ := strings.Cut(line, "|")
+	for _, field := range strings.Fields(ruleStr) {
+		if rs, err = parseRule(rs, field); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	fields := strings.Fields(rangeStr)
+	if len(fields) != 1 {
+		return nil, 0, 0, fmt.Errorf("expected one range after |, got %#v",
+			line)
+	}
+	first, last, err = parseRange(fields[0])
+	return rs, first, last, err
+}
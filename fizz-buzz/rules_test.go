@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	type Pair struct {
+		input    int_
+		expected string
+	}
+
+	rules := Rules{}.Add(DivisibleBy{labeled{"Fizz"}, 3}).
+		Add(DivisibleBy{labeled{"Buzz"}, 5}).
+		Add(ContainsDigit{labeled{"Seven"}, 7}).
+		Override(IsSquare{labeled{"Square"}}).
+		Override(IsPrime{labeled{"Prime"}}).
+		Add(Custom{labeled{"Big"}, func(n int_) bool { return n > 100 }})
+
+	pairs := []Pair{{0, "Square"}, {1, "Square"}, {2, "Prime"},
+		{6, "Fizz"}, {15, "FizzBuzz"}, {17, "Prime"}, {27, "FizzSeven"},
+		{36, "Square"}, {38, "38"}, {75, "FizzBuzzSeven"}, {105, "FizzBuzzBig"},
+		{-9, "Fizz"}}
+	for _, p := range pairs {
+		result := rules.Apply(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestIsSquare(t *testing.T) {
+	r := IsSquare{}
+	for n := int_(-10); n <= 10000; n++ {
+		expected := false
+		for i := int_(0); i*i <= n; i++ {
+			expected = expected || i*i == n
+		}
+		if result := r.Matches(n); expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				n, expected, result)
+		}
+	}
+	if !r.Matches(3037000499 * 3037000499) {
+		t.Fatalf("Expected the largest square below 2**63 to match")
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"3:Fizz 5:Buzz | 1..15",
+			"1 2 Fizz 4 Buzz Fizz 7 8 Fizz Buzz 11 Fizz 13 14 FizzBuzz"},
+		{"3:Fizz 5:Buzz 7:Bazz | 100..105",
+			"Buzz 101 Fizz 103 104 FizzBuzzBazz"},
+		{"prime=P square:S has3:T | 10", "S P P S P 6 P 8 S 10"},
+		{"2:a 2:b 2=c | 4", "1 c 3 c"},
+		{"has3:T 2:E | 30..33", "TE T TE T"},
+		{"| 3..1", ""},
+		{"| 0..2", "0 1 2"},
+	}
+	for _, p := range pairs {
+		result, err := fizzBuzzLine(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for _, input := range []string{"3Fizz | 10", "3: | 10", ":Fizz | 10",
+		"0:Zero | 10", "has10:X | 10", "cube:C | 10", "3:Fizz |",
+		"3:Fizz | 1..x", "3:Fizz | 1 2"} {
+		if _, err := fizzBuzzLine(input); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", input)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/carbonizer/codeeval-go/primes"
+)
+
+// STREAM_BUFFER_SIZE is how much output writeFizzBuzz builds up before
+// each write.
+const STREAM_BUFFER_SIZE = 1 << 16
+
+// CYCLE_MAX is the longest cycle of labels worth working out ahead of time.
+const CYCLE_MAX = 1 << 16
+
+// cycle returns the labels for every num mod the lcm of the divisors, with
+// "" for the numbers no rule matches.  It only works when every rule is a
+// DivisibleBy, and ok is false otherwise or if the cycle is too long.
+func (rs Rules) cycle() (labels []string, ok bool) {
+	period := uint64(1)
+	for _, r := range rs {
+		d, isDivisibleBy := r.Rule.(DivisibleBy)
+		if !isDivisibleBy || d.Divisor <= 0 {
+			return nil, false
+		}
+		var err error
+		period, err = primes.LCM(period, uint64(d.Divisor))
+		if err != nil || period > CYCLE_MAX {
+			return nil, false
+		}
+	}
+	labels = make([]string, period)
+	for i := range labels {
+		labels[i] = rs.label(int_(i))
+	}
+	return labels, true
+}
+
+// counter is a non-negative number kept as ASCII digits, so counting up by
+// one is usually just changing the last one.
+type counter struct {
+	// The digits are right-aligned, from start.  20 is enough for any
+	// int64 plus one.
+	buf   [20]byte
+	start int
+}
+
+func newCounter(num int_) *counter {
+	c := &counter{}
+	digits := strconv.AppendInt(nil, int64(num), 10)
+	c.start = len(c.buf) - len(digits)
+	copy(c.buf[c.start:], digits)
+	return c
+}
+
+// inc adds one, carrying through any 9s.
+func (c *counter) inc() {
+	i := len(c.buf) - 1
+	for ; i >= c.start && c.buf[i] == '9'; i-- {
+		c.buf[i] = '0'
+	}
+	if i < c.start {
+		c.start--
+		c.buf[c.start] = '1'
+	} else {
+		c.buf[i]++
+	}
+}
+
+func (c *counter) bytes() []byte {
+	return c.buf[c.start:]
+}
+
+// TEMPLATE_NUMS is about how many numbers a template covers.
+const TEMPLATE_NUMS = 1 << 12
+
+// template is the output for a run of whole cycles in which every number
+// has the same count of digits, separators and labels included.  Moving it
+// on to the next run only means adding to the numbers in place, since
+// everything else stays put.
+type template struct {
+	bytes []byte
+	// ends[k] is just past the digits of the kth number in bytes
+	ends []int
+	// The first number it covers, and how many it covers
+	num, step int_
+	// How many digits each number has, which advance can't change
+	digits int
+	// The digits of step, for advance
+	stepDigits []byte
+	zeros      int
+}
+
+// templateStep is how many numbers a template covers for a cycle of
+// labels.  It's also a multiple of the biggest power of 10 it can be, since
+// the digits under that never change as the template moves on.
+func templateStep(labels []string) int_ {
+	period := uint64(len(labels))
+	for pow := uint64(10000); pow > 1; pow /= 10 {
+		if step, err := primes.LCM(period, pow); err == nil &&
+			step <= 4*TEMPLATE_NUMS {
+			return int_(step)
+		}
+	}
+	return int_(max(1, TEMPLATE_NUMS/period) * period)
+}
+
+// newTemplate lays out the run of cycles from num, starting from the ' '
+// before it.
+func newTemplate(labels []string, num int_) *template {
+	t := &template{num: num, step: templateStep(labels),
+		digits: len(strconv.FormatInt(int64(num), 10))}
+	// Least significant first, past any trailing zeros
+	for n := t.step; n > 0; n /= 10 {
+		if d := byte(n % 10); d > 0 || len(t.stepDigits) > 0 {
+			t.stepDigits = append(t.stepDigits, d)
+		} else {
+			t.zeros++
+		}
+	}
+	for k := int_(0); k < t.step; k++ {
+		t.bytes = append(t.bytes, ' ')
+		if label := labels[int(k)%len(labels)]; len(label) > 0 {
+			t.bytes = append(t.bytes, label...)
+			continue
+		}
+		t.bytes = strconv.AppendInt(t.bytes, int64(num+k), 10)
+		t.ends = append(t.ends, len(t.bytes))
+	}
+	return t
+}
+
+// runFits reports whether the step numbers from num are all there before
+// last, and all have the same count of digits.
+func runFits(num, last, step int_) bool {
+	if last-num < step-1 {
+		return false
+	}
+	// The largest number with as many digits as num
+	top := int_(9)
+	for top < num && top <= (math.MaxInt-9)/10 {
+		top = top*10 + 9
+	}
+	if top < num {
+		top = math.MaxInt
+	}
+	return num+step-1 <= top
+}
+
+// fits reports whether the template covers the run from t.num up to last.
+// Once the numbers gain a digit, it needs laying out again.
+func (t *template) fits(last int_) bool {
+	return runFits(t.num, last, t.step) &&
+		len(strconv.FormatInt(int64(t.num), 10)) == t.digits
+}
+
+// advance moves the template on to the next run, adding step to every
+// number in ASCII.
+func (t *template) advance() {
+	for _, end := range t.ends {
+		i, carry := end-1-t.zeros, byte(0)
+		for j := 0; j < len(t.stepDigits) || carry > 0; i, j = i-1, j+1 {
+			d := t.bytes[i] + carry
+			if j < len(t.stepDigits) {
+				d += t.stepDigits[j]
+			}
+			if carry = 0; d > '9' {
+				d, carry = d-10, 1
+			}
+			t.bytes[i] = d
+		}
+	}
+	t.num += t.step
+}
+
+// writeFizzBuzz writes the labels for first to last to w, separated by
+// spaces, without ever holding more than about STREAM_BUFFER_SIZE of them.
+//
+// When the rules repeat with a short cycle (see Rules.cycle), the labels
+// are looked up rather than worked out.  For numbers from 0 up, whole runs
+// of cycles come from a template, and anything left over is counted in
+// ASCII rather than converted.
+func writeFizzBuzz(w io.Writer, rs Rules, first, last int_) error {
+	if first > last {
+		return nil
+	}
+	labels, isCycle := rs.cycle()
+	i := 0
+	if isCycle {
+		i = int((int64(first)%int64(len(labels)) + int64(len(labels))) %
+			int64(len(labels)))
+	}
+	var c *counter
+	if first >= 0 {
+		c = newCounter(first)
+	}
+	var t *template
+
+	buf := make([]byte, 0, 2*STREAM_BUFFER_SIZE)
+	flush := func() error {
+		_, err := w.Write(buf)
+		buf = buf[:0]
+		return err
+	}
+	for num := first; ; num++ {
+		if num != first && isCycle && i == 0 && c != nil &&
+			runFits(num, last, templateStep(labels)) {
+			if t == nil || t.num != num {
+				t = newTemplate(labels, num)
+			}
+			for t.fits(last) {
+				buf = append(buf, t.bytes...)
+				if len(buf) >= STREAM_BUFFER_SIZE {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+				t.advance()
+			}
+			if t.num-1 == last {
+				break
+			}
+			num, c = t.num, newCounter(t.num)
+		}
+
+		if num != first {
+			buf = append(buf, ' ')
+		}
+		var label string
+		if isCycle {
+			label = labels[i]
+			if i++; i == len(labels) {
+				i = 0
+			}
+		} else {
+			label = rs.label(num)
+		}
+		switch {
+		case len(label) > 0:
+			buf = append(buf, label...)
+		case c != nil:
+			buf = append(buf, c.bytes()...)
+		default:
+			buf = strconv.AppendInt(buf, int64(num), 10)
+		}
+		if c != nil {
+			c.inc()
+		}
+
+		if len(buf) >= STREAM_BUFFER_SIZE {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		// Checked here rather than in the loop, so last can be the
+		// largest int_
+		if num == last {
+			break
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// slowFizzBuzz joins Apply for each number.
+func slowFizzBuzz(rs Rules, first, last int_) string {
+	numStrs := []string{}
+	for num := first; num <= last; num++ {
+		numStrs = append(numStrs, rs.Apply(num))
+	}
+	return strings.Join(numStrs, " ")
+}
+
+func TestWriteFizzBuzz(t *testing.T) {
+	type Args struct {
+		rules       Rules
+		first, last int_
+	}
+
+	fizzBuzz := legacyRules(3, 5)
+	mixed := Rules{}.Add(DivisibleBy{labeled{"Fizz"}, 3}).
+		Add(ContainsDigit{labeled{"Seven"}, 7})
+	args := []Args{{fizzBuzz, 1, 0}, {fizzBuzz, 1, 1}, {fizzBuzz, 0, 15},
+		{fizzBuzz, -20, 20}, {fizzBuzz, 95, 1005}, {mixed, -30, 130},
+		{Rules{}, 9990, 10010}, {legacyRules(1<<20, 3), 1, 50},
+		// Longer than the buffer, and templates that cross into more
+		// digits or run up against the end
+		{fizzBuzz, 1, 3 * STREAM_BUFFER_SIZE}, {fizzBuzz, 9e5, 1e6 + 9001},
+		{legacyRules(4, 25), 98765, 198765}, {legacyRules(7, 11), 0, 1e5},
+		{fizzBuzz, math.MaxInt - 3e4, math.MaxInt - 1},
+		// Periods that divide a power of 10, so a template runs right
+		// up to where the numbers gain a digit
+		{legacyRules(2, 5), 1, 130000}, {legacyRules(4, 5), 1, 130000}}
+	for i := 0; i < 20; i++ {
+		first := int_(rand.Intn(2e6) - 1e6)
+		rules := legacyRules(int_(rand.Intn(20)+1), int_(rand.Intn(20)+1))
+		args = append(args, Args{rules, first, first + int_(rand.Intn(5e4))})
+	}
+	for _, a := range args {
+		expected := slowFizzBuzz(a.rules, a.first, a.last)
+		sb := &strings.Builder{}
+		if err := writeFizzBuzz(sb, a.rules, a.first, a.last); err != nil {
+			t.Fatal(err)
+		}
+		if result := sb.String(); expected != result {
+			t.Fatalf("Input: %v..%v\nExpected: %.200q\n     Got: %.200q\n",
+				a.first, a.last, expected, result)
+		}
+	}
+
+	// Ending at the largest int_ doesn't wrap around
+	sb := &strings.Builder{}
+	writeFizzBuzz(sb, Rules{}, math.MaxInt-1, math.MaxInt)
+	if expected := "9223372036854775806 9223372036854775807"; expected !=
+		sb.String() {
+		t.Fatalf("Expected %#v, got %#v", expected, sb.String())
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteFizzBuzzError(t *testing.T) {
+	if err := writeFizzBuzz(failingWriter{}, legacyRules(3, 5), 1, 1e6); err ==
+		nil {
+		t.Fatalf("Expected the write error")
+	}
+}
+
+func TestCounter(t *testing.T) {
+	for _, start := range []int_{0, 8, 99, 12345, 999999, 1e18 - 2} {
+		c := newCounter(start)
+		for num := start; num < start+20; num++ {
+			if expected := strconv.Itoa(int(num)); expected !=
+				string(c.bytes()) {
+				t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+					num, expected, string(c.bytes()))
+			}
+			c.inc()
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	labels, ok := legacyRules(3, 5).cycle()
+	expected := []string{"FB", "", "", "F", "", "B", "F", "", "", "F", "B",
+		"", "F", "", ""}
+	if !ok || strings.Join(expected, ",") != strings.Join(labels, ",") {
+		t.Fatalf("Expected %#v, got %#v", expected, labels)
+	}
+	for _, rules := range []Rules{legacyRules(1<<20, 3),
+		Rules{}.Add(IsPrime{labeled{"P"}})} {
+		if _, ok := rules.cycle(); ok {
+			t.Fatalf("Expected no cycle for %#v", rules)
+		}
+	}
+}
+
+func BenchmarkWriteFizzBuzz(b *testing.B) {
+	// Count the bytes for one run, so the benchmark reports throughput
+	n := int_(1e7)
+	counter := &countingWriter{}
+	writeFizzBuzz(counter, legacyRules(3, 5), 1, n)
+	b.SetBytes(counter.n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		writeFizzBuzz(io.Discard, legacyRules(3, 5), 1, n)
+	}
+}
+
+func BenchmarkStreamFizzBuzzPipe(b *testing.B) {
+	// The same, through bufio and a real pipe like main uses
+	stdin := []byte("3 5 10000000\n")
+	counter := &countingWriter{}
+	streamFizzBuzz(stdin, counter)
+	b.SetBytes(counter.n)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		b.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, r)
+		close(done)
+	}()
+	out := bufio.NewWriterSize(w, STREAM_BUFFER_SIZE)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := streamFizzBuzz(stdin, out); err != nil {
+			b.Fatal(err)
+		}
+	}
+	out.Flush()
+	w.Close()
+	<-done
+}
+
+// countingWriter counts what is written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
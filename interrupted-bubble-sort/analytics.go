@@ -0,0 +1,76 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+)
+
+// inversions counts the pairs in s that are out of order by cmp, i.e.
+// i < j but s[i] > s[j].  Equal values aren't out of order.
+//
+// This is a merge sort that counts as it goes.  Whenever an element from
+// the right half is merged in ahead of elements still waiting in the left
+// half, it was out of order with every one of them.  That makes it
+// O(n log n) instead of checking every pair.
+func inversions[T any](s []T, cmp func(a, b T) int) int {
+	sorted := slices.Clone(s)
+	buf := make([]T, len(s))
+	return mergeCount(sorted, buf, cmp)
+}
+
+// mergeCount sorts s, using buf as scratch space, and returns the number of
+// inversions it fixed.
+func mergeCount[T any](s, buf []T, cmp func(a, b T) int) int {
+	if len(s) < 2 {
+		return 0
+	}
+	mid := len(s) / 2
+	count := mergeCount(s[:mid], buf[:mid], cmp) +
+		mergeCount(s[mid:], buf[mid:], cmp)
+
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(s) {
+		// Taking from the left on ties keeps equal values in order
+		if cmp(s[i], s[j]) <= 0 {
+			buf[k] = s[i]
+			i++
+		} else {
+			buf[k] = s[j]
+			j++
+			count += mid - i
+		}
+		k++
+	}
+	k += copy(buf[k:], s[i:mid])
+	copy(buf[k:], s[j:])
+	copy(s, buf[:len(s)])
+	return count
+}
+
+// kendallTau returns the normalized Kendall tau distance from s to sorted
+// order.  The distance is the number of pairs the two orders disagree on,
+// which for sorted order is just the inversions, divided by the number of
+// pairs.  It is 0 for sorted and 1 for reverse sorted with no repeats.
+func kendallTau[T any](s []T, cmp func(a, b T) int) float64 {
+	n := len(s)
+	if n < 2 {
+		return 0
+	}
+	return float64(inversions(s, cmp)) / float64(n*(n-1)/2)
+}
+
+// analytics answers questions about s without sorting it.  The name is
+// whatever came after the " | " in place of the max.
+func analytics[T any](name string, s []T, cmp func(a, b T) int) (
+	string, bool) {
+	switch name {
+	case "passes":
+		// Not counting bubbleSortMax's last pass that only checks
+		return strconv.Itoa(maxLeftShift(s, cmp)), true
+	case "inversions":
+		return strconv.Itoa(inversions(s, cmp)), true
+	case "kendall":
+		return strconv.FormatFloat(kendallTau(s, cmp), 'g', -1, 64), true
+	}
+	return "", false
+}
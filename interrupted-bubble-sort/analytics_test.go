@@ -0,0 +1,120 @@
+package main
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// slowInversions counts the out of order pairs the slow way.
+func slowInversions(nums []int) int {
+	count := 0
+	for i := range nums {
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i] > nums[j] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestInversions(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for trial := 0; trial < 500; trial++ {
+		nums := make([]int, rng.Intn(50))
+		for i := range nums {
+			nums[i] = rng.Intn(20)
+		}
+		input := slices.Clone(nums)
+
+		expected := slowInversions(nums)
+		if result := inversions(nums, cmp.Compare[int]); expected != result {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				nums, expected, result)
+		}
+		if !slices.Equal(input, nums) {
+			t.Fatalf("Input was modified: %v", nums)
+		}
+
+		// bubbleSortMax takes one more pass to notice it is done
+		sorted := slices.Clone(nums)
+		if passes := bubbleSortMax(sorted, len(nums)+1).Passes; len(nums) > 1 &&
+			passes != maxLeftShift(nums, cmp.Compare[int])+1 {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				nums, passes-1, maxLeftShift(nums, cmp.Compare[int]))
+		}
+	}
+}
+
+func TestKendallTau(t *testing.T) {
+	type Pair struct {
+		input    []int
+		expected float64
+	}
+
+	pairs := []Pair{
+		{[]int{}, 0},
+		{[]int{1, 2, 3, 4}, 0},
+		{[]int{4, 3, 2, 1}, 1},
+		{[]int{2, 1, 3, 4}, 1.0 / 6},
+		{[]int{1, 1, 1}, 0},
+	}
+	for _, p := range pairs {
+		if result := kendallTau(p.input, cmp.Compare[int]); p.expected != result {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestBubbleSortPassesCounts(t *testing.T) {
+	// The shortcut's counts match actually doing the passes
+	rng := rand.New(rand.NewSource(5))
+	for trial := 0; trial < 500; trial++ {
+		nums := make([]int, rng.Intn(30))
+		for i := range nums {
+			nums[i] = rng.Intn(10)
+		}
+		max := rng.Intn(35)
+
+		s := stepper{data: sort.IntSlice(slices.Clone(nums)),
+			lt: LIMIT_PASSES, max: max}
+		bubbleSteps(&s)
+		result := bubbleSortPassesCounts(slices.Clone(nums), max, cmp.Compare[int])
+		if s.Counts != result {
+			t.Fatalf("Input: %v | %v\nExpected: %+v\n     Got: %+v\n",
+				nums, max, s.Counts, result)
+		}
+	}
+}
+
+func TestInterruptedBubbleSortAnalytics(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{{
+		`48 51 5 61 18 | passes
+48 51 5 61 18 | inversions
+4 3 2 1 | kendall
+48 51 5 61 18 | 2 | bubble | counts
+48 51 5 61 18 | 2 | selection swaps | counts`,
+		`3
+5
+1
+5 48 18 51 61 | 8 4 2
+5 18 48 61 51 | 7 2 2`}}
+	for _, p := range pairs {
+		result, err := InterruptedBubbleSort([]byte(p.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Sizes used when drawing a trace
+const (
+	// Longest bar in the terminal, in characters
+	TERM_BAR_WIDTH = 50
+	// Width of each bar and height of the tallest in SVGs and GIFs, in
+	// pixels
+	IMAGE_BAR_WIDTH  = 12
+	IMAGE_BAR_HEIGHT = 150
+	// Room under the bars in SVGs and GIFs, in pixels
+	IMAGE_MARGIN = 20
+)
+
+// highlight is how the bar at index idx should stand out in the frame.  It
+// returns EVENT_PASS for bars that shouldn't.
+func (f Frame) highlight(idx int) EventKind {
+	if f.Kind != EVENT_PASS && (idx == f.I || idx == f.J) {
+		return f.Kind
+	}
+	return EVENT_PASS
+}
+
+// barLengths scales values so the smallest is 1 long and the largest is
+// longest long.  The scale is taken from the trace's initial values so it
+// stays the same in every frame.
+func (tr *Trace) barLengths(values []int, longest int) []int {
+	lengths := make([]int, len(values))
+	if len(values) == 0 {
+		return lengths
+	}
+	lo, hi := slices.Min(tr.Initial), slices.Max(tr.Initial)
+	for i, v := range values {
+		lengths[i] = longest
+		if hi > lo {
+			lengths[i] = 1 + (v-lo)*(longest-1)/(hi-lo)
+		}
+	}
+	return lengths
+}
+
+//
+// Terminal
+//
+
+// ANSI escape codes for the terminal animation
+const (
+	ANSI_CLEAR  = "\x1b[H\x1b[2J"
+	ANSI_YELLOW = "\x1b[33m"
+	ANSI_RED    = "\x1b[31m"
+	ANSI_RESET  = "\x1b[0m"
+)
+
+// drawFrame draws a frame as one horizontal bar per value.
+func (tr *Trace) drawFrame(f Frame) string {
+	var sb strings.Builder
+	sb.WriteString(f.Describe() + "\n")
+	for i, length := range tr.barLengths(f.Values, TERM_BAR_WIDTH) {
+		bar := strings.Repeat("█", length)
+		switch f.highlight(i) {
+		case EVENT_COMPARE:
+			bar = ANSI_YELLOW + bar + ANSI_RESET
+		case EVENT_SWAP:
+			bar = ANSI_RED + bar + ANSI_RESET
+		}
+		fmt.Fprintf(&sb, "%6s %s\n", f.Labels[i], bar)
+	}
+	return sb.String()
+}
+
+// Animate replays the trace in a terminal, clearing it and drawing each
+// frame in turn, delay apart.  Compared values are yellow and swapped ones
+// are red.
+func (tr *Trace) Animate(w io.Writer, delay time.Duration) error {
+	for i, f := range tr.Frames() {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		if _, err := io.WriteString(w, ANSI_CLEAR+tr.drawFrame(f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//
+// Images
+//
+
+// Colors used in SVGs and GIFs
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorBar        = color.RGBA{0x77, 0x77, 0x77, 0xff}
+	colorCompare    = color.RGBA{0xee, 0xbb, 0x00, 0xff}
+	colorSwap       = color.RGBA{0xdd, 0x22, 0x22, 0xff}
+)
+
+// barColor is the color of the bar at index idx in the frame.
+func (f Frame) barColor(idx int) color.RGBA {
+	switch f.highlight(idx) {
+	case EVENT_COMPARE:
+		return colorCompare
+	case EVENT_SWAP:
+		return colorSwap
+	}
+	return colorBar
+}
+
+// hex formats c for SVG, e.g. "#ff0000".
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// WriteSVG writes the trace as a single animated SVG with one vertical bar
+// per value.  Each frame is a group that is only visible for its share of
+// the animation, and the last one stays up at the end.
+func (tr *Trace) WriteSVG(w io.Writer, frameDur time.Duration) error {
+	width := len(tr.Initial) * IMAGE_BAR_WIDTH
+	height := IMAGE_BAR_HEIGHT + IMAGE_MARGIN
+	secs := frameDur.Seconds()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" `+
+		`width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n",
+		hex(colorBackground))
+
+	frames := tr.Frames()
+	for i, f := range frames {
+		// Everything starts hidden and is shown at its turn
+		sb.WriteString(`<g visibility="hidden">`)
+		if i == len(frames)-1 {
+			fmt.Fprintf(&sb, `<set attributeName="visibility" `+
+				`to="visible" begin="%.3fs" fill="freeze"/>`,
+				float64(i)*secs)
+		} else {
+			fmt.Fprintf(&sb, `<set attributeName="visibility" `+
+				`to="visible" begin="%.3fs" dur="%.3fs"/>`,
+				float64(i)*secs, secs)
+		}
+		for j, length := range tr.barLengths(f.Values, IMAGE_BAR_HEIGHT) {
+			fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" `+
+				`height="%d" fill="%s"/>`,
+				j*IMAGE_BAR_WIDTH+1, IMAGE_BAR_HEIGHT-length,
+				IMAGE_BAR_WIDTH-2, length, hex(f.barColor(j)))
+		}
+		fmt.Fprintf(&sb, `<text x="2" y="%d" font-size="12">%s</text>`,
+			height-4, f.Describe())
+		sb.WriteString("</g>\n")
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteGIF writes the trace as an animated GIF, one frame per event.
+func (tr *Trace) WriteGIF(w io.Writer, frameDur time.Duration) error {
+	palette := color.Palette{
+		colorBackground, colorBar, colorCompare, colorSwap}
+	bounds := image.Rect(0, 0, max(len(tr.Initial)*IMAGE_BAR_WIDTH, 1),
+		IMAGE_BAR_HEIGHT+IMAGE_MARGIN)
+	// GIF delays are in hundredths of a second
+	delay := int(frameDur / (10 * time.Millisecond))
+
+	anim := &gif.GIF{}
+	for _, f := range tr.Frames() {
+		img := image.NewPaletted(bounds, palette)
+		draw.Draw(img, bounds, image.NewUniform(colorBackground),
+			image.Point{}, draw.Src)
+		for j, length := range tr.barLengths(f.Values, IMAGE_BAR_HEIGHT) {
+			bar := image.Rect(j*IMAGE_BAR_WIDTH+1,
+				IMAGE_BAR_HEIGHT-length,
+				(j+1)*IMAGE_BAR_WIDTH-1, IMAGE_BAR_HEIGHT)
+			draw.Draw(img, bar, image.NewUniform(f.barColor(j)),
+				image.Point{}, draw.Src)
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"image/gif"
+	"strings"
+	"testing"
+)
+
+func TestAnimate(t *testing.T) {
+	tr, _, _ := traceSort("bubble", []int{3, 1, 2}, 10, LIMIT_PASSES,
+		cmp.Compare[int])
+	var buf bytes.Buffer
+	if err := tr.Animate(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if count := strings.Count(out, ANSI_CLEAR); count != len(tr.Events)+1 {
+		t.Fatalf("Expected %v frames, got %v", len(tr.Events)+1, count)
+	}
+	// The first swap highlights the 3 and the 1
+	first := strings.Split(out, ANSI_CLEAR)[3]
+	expected := "pass 1: swap 0 and 1\n" +
+		"     1 " + ANSI_RED + "█" + ANSI_RESET + "\n" +
+		"     3 " + ANSI_RED + strings.Repeat("█", TERM_BAR_WIDTH) +
+		ANSI_RESET + "\n" +
+		"     2 " + strings.Repeat("█", 25) + "\n"
+	if expected != first {
+		t.Fatalf("Expected:\n%v\nGot:\n%v", expected, first)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	tr, _, _ := traceSort("insertion", []int{4, 3, 2, 1}, 10, LIMIT_PASSES,
+		cmp.Compare[int])
+	var buf bytes.Buffer
+	if err := tr.WriteSVG(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "<svg ") ||
+		!strings.HasSuffix(out, "</svg>\n") {
+		t.Fatalf("Expected an SVG, got %v", out)
+	}
+	if count := strings.Count(out, "<g "); count != len(tr.Events)+1 {
+		t.Fatalf("Expected %v frames, got %v", len(tr.Events)+1, count)
+	}
+	if count := strings.Count(out, `fill="freeze"`); count != 1 {
+		t.Fatalf("Expected only the last frame to stay, got %v", count)
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	tr, _, _ := traceSort("gnome", []int{4, 3, 2, 1}, 10, LIMIT_PASSES,
+		cmp.Compare[int])
+	var buf bytes.Buffer
+	if err := tr.WriteGIF(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != len(tr.Events)+1 {
+		t.Fatalf("Expected %v frames, got %v",
+			len(tr.Events)+1, len(anim.Image))
+	}
+	width := anim.Image[0].Bounds().Dx()
+	if width != 4*IMAGE_BAR_WIDTH {
+		t.Fatalf("Expected a width of %v, got %v", 4*IMAGE_BAR_WIDTH, width)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// indexed is a value along with where it started, so equal values can be
+// told apart.
+type indexed[T any] struct {
+	val T
+	idx int
+}
+
+// indexedHeap is a min-heap for container/heap.  Equal values come out in
+// the order they went in, which is what keeps bubble sort stable.
+type indexedHeap[T any] struct {
+	items []indexed[T]
+	cmp   func(a, b T) int
+}
+
+func (h *indexedHeap[T]) Len() int { return len(h.items) }
+
+func (h *indexedHeap[T]) Less(i, j int) bool {
+	c := h.cmp(h.items[i].val, h.items[j].val)
+	return c < 0 || (c == 0 && h.items[i].idx < h.items[j].idx)
+}
+
+func (h *indexedHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *indexedHeap[T]) Push(x any) {
+	h.items = append(h.items, x.(indexed[T]))
+}
+
+func (h *indexedHeap[T]) Pop() any {
+	x := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return x
+}
+
+// applyPasses puts s in the same state k passes of bubble sort would,
+// without doing the passes.
+//
+// During a pass, the largest value seen so far is carried to the right and
+// everything else moves left by at most one.  Over k passes, that means an
+// element can only end up at position j if it started somewhere in
+// s[:j+k+1], and the smallest such element that hasn't been placed yet is
+// the one that does.  Sliding a min-heap of k+1 elements across s picks them
+// out in O(n log k) rather than O(n k).  Whatever is left in the heap at the
+// end is the k largest, which the passes already put in order.
+func applyPasses[T any](s []T, k int, cmp func(a, b T) int) {
+	k = min(k, len(s))
+	if k <= 0 {
+		return
+	}
+
+	h := &indexedHeap[T]{make([]indexed[T], 0, k+1), cmp}
+	j := 0
+	for i, val := range s {
+		heap.Push(h, indexed[T]{val, i})
+		if h.Len() > k {
+			// j never gets ahead of the element being read, so
+			// writing in place is safe
+			s[j] = heap.Pop(h).(indexed[T]).val
+			j++
+		}
+	}
+	for h.Len() > 0 {
+		s[j] = heap.Pop(h).(indexed[T]).val
+		j++
+	}
+}
+
+// maxLeftShift returns the number of passes bubble sort needs to sort s,
+// not counting the last pass that only checks.
+//
+// Every pass moves each element with something larger to its left one
+// place left, so the answer is the most larger elements any one element has
+// in front of it.  A Fenwick tree over the ranks of the values counts them
+// in O(n log n).
+func maxLeftShift[T any](s []T, cmp func(a, b T) int) int {
+	sorted := slices.Clone(s)
+	slices.SortFunc(sorted, cmp)
+	sorted = slices.CompactFunc(sorted, func(a, b T) bool {
+		return cmp(a, b) == 0
+	})
+
+	// tree[r] covers some count of values with rank <= r (1-based)
+	tree := make([]int, len(sorted)+1)
+	most := 0
+	for i, val := range s {
+		rank, _ := slices.BinarySearchFunc(sorted, val, cmp)
+		rank++
+
+		// How many of the i elements before this one are <= val
+		notGreater := 0
+		for r := rank; r > 0; r -= r & -r {
+			notGreater += tree[r]
+		}
+		most = max(most, i-notGreater)
+
+		for r := rank; r < len(tree); r += r & -r {
+			tree[r]++
+		}
+	}
+	return most
+}
+
+// bubbleSortPasses is bubbleSortMax without the iterations.  It leaves nums
+// in the same state and returns the same count, but it takes O(n log n) no
+// matter how big max is.
+func bubbleSortPasses(nums []int, max int) int {
+	return bubbleSortPassesCounts(nums, max, cmp.Compare[int]).Passes
+}
+
+// bubbleSortPassesCounts is bubbleSortPasses for any type, and it returns
+// all of the counts.  Every pass compares each neighbouring pair once, and
+// every swap fixes exactly one inversion, so those can be worked out too.
+func bubbleSortPassesCounts[T any](s []T, max int, cmp func(a, b T) int) Counts {
+	if len(s) < 2 || max <= 0 {
+		return Counts{}
+	}
+
+	needed := maxLeftShift(s, cmp)
+	before := inversions(s, cmp)
+	applyPasses(s, min(needed, max), cmp)
+
+	// bubbleSortMax counts the pass that finds nothing left to swap
+	passes := max
+	if needed < max {
+		passes = needed + 1
+	}
+	return Counts{
+		Comparisons: passes * (len(s) - 1),
+		Swaps:       before - inversions(s, cmp),
+		Passes:      passes,
+	}
+}
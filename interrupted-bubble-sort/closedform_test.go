@@ -0,0 +1,77 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestApplyPasses(t *testing.T) {
+	type Args struct {
+		nums []int
+		k    int
+	}
+	type Pair struct {
+		input    Args
+		expected []int
+	}
+
+	pairs := []Pair{
+		{Args{[]int{48, 51, 5, 61, 18}, 0}, []int{48, 51, 5, 61, 18}},
+		{Args{[]int{48, 51, 5, 61, 18}, 1}, []int{48, 5, 51, 18, 61}},
+		{Args{[]int{48, 51, 5, 61, 18}, 2}, []int{5, 48, 18, 51, 61}},
+		{Args{[]int{48, 51, 5, 61, 18}, 1e9}, []int{5, 18, 48, 51, 61}},
+		{Args{[]int{}, 3}, []int{}},
+	}
+	for _, p := range pairs {
+		result := slices.Clone(p.input.nums)
+		applyPasses(result, p.input.k, cmp.Compare[int])
+		if fmt.Sprint(p.expected) != fmt.Sprint(result) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestBubbleSortPassesMatchesSimulation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 2000; trial++ {
+		// Small values so there are plenty of duplicates
+		nums := make([]int, rng.Intn(40))
+		for i := range nums {
+			nums[i] = rng.Intn(20) - 5
+		}
+		max := rng.Intn(50) - 2
+
+		expected := slices.Clone(nums)
+		expectedCounts := bubbleSortMax(expected, max)
+		result := slices.Clone(nums)
+		resultCounts := bubbleSortPassesCounts(result, max,
+			cmp.Compare[int])
+
+		if !slices.Equal(expected, result) || expectedCounts != resultCounts {
+			t.Fatalf("Input: %v | %v\nExpected: %v (%+v)\n     Got: %v (%+v)\n",
+				nums, max, expected, expectedCounts, result, resultCounts)
+		}
+		count := bubbleSortPasses(slices.Clone(nums), max)
+		if count != expectedCounts.Passes {
+			t.Fatalf("Input: %v | %v\nExpected: %v\n     Got: %v\n",
+				nums, max, expectedCounts.Passes, count)
+		}
+	}
+}
+
+func TestBubbleSortPassesLarge(t *testing.T) {
+	// Far too many passes to simulate
+	rng := rand.New(rand.NewSource(2))
+	nums := rng.Perm(200000)
+	count := bubbleSortPasses(nums, 3000000000)
+	if !slices.IsSorted(nums) {
+		t.Fatal("Expected nums to be sorted")
+	}
+	if count > len(nums) {
+		t.Fatalf("Expected at most %v passes, got %v", len(nums), count)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/sliceutil"
+)
+
+// BubbleSortMax is bubbleSortMax for any ordered type.  Floats are ordered
+// by cmp.Compare, so NaNs go first rather than stopping the sort.  Like
+// bubbleSortMax, it is stable and returns the number of passes used, but it
+// takes O(n log n) no matter how big max is.
+func BubbleSortMax[T cmp.Ordered](s []T, max int) int {
+	return BubbleSortMaxFunc(s, max, cmp.Compare[T])
+}
+
+// BubbleSortMaxFunc is BubbleSortMax ordered by cmp, which returns a
+// negative number when a belongs before b, a positive number when it
+// belongs after, and 0 when either will do.  Values cmp says are equal keep
+// their order, so records can be sorted by a key.
+func BubbleSortMaxFunc[T any](s []T, max int, cmp func(a, b T) int) int {
+	return bubbleSortPassesCounts(s, max, cmp).Passes
+}
+
+// BubbleSortMaxInterface is BubbleSortMax for anything that implements
+// sort.Interface.  Only Less and Swap are available, so the passes are done
+// for real.
+func BubbleSortMaxInterface(data sort.Interface, max int) int {
+	s := stepper{data: data, lt: LIMIT_PASSES, max: max}
+	bubbleSteps(&s)
+	return s.Passes
+}
+
+// Descending reverses cmp, so the largest values go first.  Equal values
+// are still equal, so stable sorts stay stable.
+func Descending[T any](cmp func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int { return cmp(b, a) }
+}
+
+// sortLine answers one input line once the values are parsed.  rest is
+// everything after the values, and format turns the values back into text.
+func sortLine[T any](vals []T, cmp func(a, b T) int, rest []string,
+	format func([]T) string) (string, error) {
+	if answer, ok := analytics(rest[0], vals, cmp); ok {
+		return answer, nil
+	}
+
+	max, err := strconv.Atoi(rest[0])
+	if err != nil {
+		return "", err
+	}
+
+	spec := ""
+	if len(rest) >= 2 {
+		spec = rest[1]
+	}
+	ss, err := parseSortSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	if ss.desc {
+		cmp = Descending(cmp)
+	}
+
+	// The last part asks for counts or a trace
+	showCounts, traceOpt := false, []string(nil)
+	if len(rest) == 3 {
+		fields := strings.Fields(rest[2])
+		switch {
+		case rest[2] == "counts":
+			showCounts = true
+		case len(fields) > 0 && fields[0] == "trace":
+			traceOpt = fields[1:]
+		default:
+			return "", fmt.Errorf("Cannot parse %#v", rest[2])
+		}
+	}
+
+	var counts Counts
+	if traceOpt != nil {
+		var tr *Trace
+		if tr, counts, err = traceSort(ss.name, vals, max, ss.lt,
+			cmp); err != nil {
+			return "", err
+		}
+		if err := writeTrace(tr, traceOpt); err != nil {
+			return "", err
+		}
+	} else {
+		// For plain bubble sort by passes, this gives the same result
+		// as bubbleSortMax, but fast for huge max
+		if counts, err = SortMaxFunc(ss.name, vals, max, ss.lt,
+			cmp); err != nil {
+			return "", err
+		}
+	}
+
+	out := format(vals)
+	if showCounts {
+		out += fmt.Sprintf(" | %d %d %d", counts.Comparisons,
+			counts.Swaps, counts.Passes)
+	}
+	return out, nil
+}
+
+// sortAnyLine works out whether the values on a line are ints, floats or
+// strings, and sorts them as that.  Every value has to parse for a line to
+// count as numbers, so "1 2 x" is three strings.
+func sortAnyLine(valsStr string, rest []string) (string, error) {
+	fields := strings.Fields(valsStr)
+
+	if ints, err := sliceutil.ParseAll[int64](fields, 10, 0); err == nil {
+		return sortLine(ints, cmp.Compare[int64], rest,
+			func(s []int64) string { return sliceutil.Join(s, " ") })
+	}
+	if floats, err := sliceutil.ParseAll[float64](fields, 10, 0); err == nil {
+		return sortLine(floats, cmp.Compare[float64], rest,
+			func(s []float64) string { return sliceutil.Join(s, " ") })
+	}
+	return sortLine(slices.Clone(fields), strings.Compare, rest,
+		func(s []string) string { return strings.Join(s, " ") })
+}
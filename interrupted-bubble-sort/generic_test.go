@@ -0,0 +1,124 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestBubbleSortMax(t *testing.T) {
+	floats := []float64{3.5, 1.25, -2, 10}
+	if count := BubbleSortMax(floats, 1); count != 1 ||
+		fmt.Sprint(floats) != "[1.25 -2 3.5 10]" {
+		t.Fatalf("Expected [1.25 -2 3.5 10] in 1, got %v in %v",
+			floats, count)
+	}
+
+	strs := []string{"pear", "apple", "fig", "banana"}
+	if count := BubbleSortMax(strs, 10); count != 3 ||
+		fmt.Sprint(strs) != "[apple banana fig pear]" {
+		t.Fatalf("Expected [apple banana fig pear] in 3, got %v in %v",
+			strs, count)
+	}
+
+	// Same as bubbleSortMax for ints
+	rng := rand.New(rand.NewSource(6))
+	for i := 0; i < 100; i++ {
+		nums := rng.Perm(10)
+		max := rng.Intn(12)
+		expected := slices.Clone(nums)
+		expectedCount := bubbleSortMax(expected, max).Passes
+		if count := BubbleSortMax(nums, max); expectedCount != count ||
+			!slices.Equal(expected, nums) {
+			t.Fatalf("Expected %v in %v, got %v in %v",
+				expected, expectedCount, nums, count)
+		}
+	}
+}
+
+// record is something sorted by key, with tag to tell equal keys apart.
+type record struct {
+	key int
+	tag string
+}
+
+func TestBubbleSortMaxFuncStable(t *testing.T) {
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	type Pair struct {
+		input    func(a, b record) int
+		expected string
+	}
+
+	pairs := []Pair{
+		{byKey, "[{1 b} {1 d} {2 a} {2 c}]"},
+		{Descending(byKey), "[{2 a} {2 c} {1 b} {1 d}]"},
+	}
+	for _, p := range pairs {
+		records := []record{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}}
+		BubbleSortMaxFunc(records, 10, p.input)
+		if result := fmt.Sprint(records); p.expected != result {
+			t.Fatalf("Expected %v, got %v", p.expected, result)
+		}
+	}
+}
+
+func TestStepSortsStable(t *testing.T) {
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	rng := rand.New(rand.NewSource(7))
+	for _, name := range []string{
+		"bubble", "insertion", "cocktail", "oddeven", "gnome"} {
+		for _, lt := range []LimitType{LIMIT_PASSES, LIMIT_SWAPS} {
+			for i := 0; i < 20; i++ {
+				records := make([]record, 12)
+				for j := range records {
+					records[j] = record{rng.Intn(4), fmt.Sprint(j)}
+				}
+				tags := map[string]int{}
+				for j, r := range records {
+					tags[r.tag] = j
+				}
+
+				_, err := SortMaxFunc(name, records, rng.Intn(15), lt,
+					byKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// Equal keys never change order, even part way through
+				for j := 1; j < len(records); j++ {
+					a, b := records[j-1], records[j]
+					if a.key == b.key && tags[a.tag] > tags[b.tag] {
+						t.Fatalf("%v %v is not stable: %v",
+							name, lt, records)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBubbleSortMaxInterface(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	for i := 0; i < 100; i++ {
+		nums := rng.Perm(10)
+		max := rng.Intn(12)
+		expected := slices.Clone(nums)
+		expectedCount := bubbleSortMax(expected, max).Passes
+		count := BubbleSortMaxInterface(sort.IntSlice(nums), max)
+		if expectedCount != count || !slices.Equal(expected, nums) {
+			t.Fatalf("Expected %v in %v, got %v in %v",
+				expected, expectedCount, nums, count)
+		}
+	}
+
+	// Reversed by the interface instead
+	nums := []int{1, 2, 3}
+	BubbleSortMaxInterface(sort.Reverse(sort.IntSlice(nums)), 10)
+	if fmt.Sprint(nums) != "[3 2 1]" {
+		t.Fatalf("Expected [3 2 1], got %v", nums)
+	}
+}
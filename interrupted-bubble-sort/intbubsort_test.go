@@ -31,3 +31,59 @@ func TestInterruptedBubbleSort(t *testing.T) {
 		}
 	}
 }
+
+func TestInterruptedBubbleSortOtherSorts(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{{
+		`5 1 4 2 3 | 2 | insertion
+5 1 4 2 3 | 3 | bubble swaps
+5 1 4 2 3 | 1 | shell passes`,
+		`1 4 5 2 3
+1 4 2 5 3
+3 1 4 2 5`}}
+	for _, p := range pairs {
+		result, err := InterruptedBubbleSort([]byte(p.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestInterruptedBubbleSortTypes(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{{
+		`9000000000 -1 3 | 1
+3.5 1.25 -2 10 | 1
+pear apple fig banana | 1
+1 2 x | 1
+5 18 48 61 51 | 1 | bubble desc
+pear apple fig banana | 5 | insertion swaps desc
+0.5 0.25 | inversions`,
+		`-1 3 9000000000
+1.25 -2 3.5 10
+apple fig banana pear
+1 2 x
+18 48 61 51 5
+pear fig banana apple
+1`}}
+	for _, p := range pairs {
+		result, err := InterruptedBubbleSort([]byte(p.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
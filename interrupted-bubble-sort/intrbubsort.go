@@ -10,7 +10,6 @@ import (
 
 // Imports for the specific problem
 import (
-	"strconv"
 	"strings"
 )
 
@@ -111,76 +110,66 @@ func main() {
 	inputToFuncToStdout(InterruptedBubbleSort, INPUT_FILEARG)
 }
 
-// strsToInts converts a slice of strings to a slice of ints
-func strsToInts(strs []string) ([]int, error) {
-	ints := make([]int, len(strs))
-	for i, str := range strs {
-		int_, err := strconv.Atoi(str)
-		if err != nil {
-			return ints, err
-		}
-		ints[i] = int_
-	}
-	return ints, nil
-}
-
-// intsToStrs converts a slice of ints to a slice of strings
-func intsToStrs(ints []int) []string {
-	strs := make([]string, len(ints))
-	for i, int_ := range ints {
-		str := strconv.Itoa(int_)
-		strs[i] = str
-	}
-	return strs
-}
-
 // bubbleSortMax applies a bubble sort in place, limited to max iterations.
-// It returns the actual number of iterations used.
-func bubbleSortMax(nums []int, max int) int {
+// It returns the comparisons, swaps and iterations actually used.
+func bubbleSortMax(nums []int, max int) Counts {
+	var counts Counts
 	if len(nums) < 2 {
-		return 0
+		return counts
 	}
 
-	count := 0
-	for ; count < max; count++ {
+	for ; counts.Passes < max; counts.Passes++ {
 		isChanged := false
 		// i is the index of first and i+1 is the index of second
 		for i, second := range nums[1:] {
+			counts.Comparisons++
 			if nums[i] > second {
 				nums[i], nums[i+1] = second, nums[i]
+				counts.Swaps++
 				isChanged = true
 			}
 		}
 		// Already sorted
 		if !isChanged {
 			// Since we are breaking, count this loop manually
-			count++
+			counts.Passes++
 			break
 		}
 	}
-	return count
+	return counts
 }
 
+// InterruptedBubbleSort handles lines of "<nums> | <max>", with an optional
+// third part picking another sort and what max limits, e.g.
+// "<nums> | <max> | insertion swaps".  See stepSorts for the choices.  A
+// fourth part of "counts" adds how many comparisons, swaps and passes were
+// used after the nums, like "<nums> | <comparisons> <swaps> <passes>".  A
+// fourth part of "trace term", "trace svg <file>" or "trace gif <file>"
+// replays the sort instead, animated on stdout ahead of the answers or
+// saved to the file (see writeTrace).
+//
+// In place of max, "passes", "inversions" or "kendall" answers that question
+// about the nums without sorting them (see analytics).
+//
+// The nums don't have to be ints.  A line of floats is sorted as floats,
+// and anything else as strings (see sortAnyLine).  Adding "desc" to the
+// third part sorts largest first, e.g. "<nums> | <max> | bubble desc".
 func InterruptedBubbleSort(input []byte) (interface{}, error) {
 	lines := strings.Split(strings.TrimRight(string(input), "\n"), "\n")
 	outLines := make([]string, len(lines))
 	for i, line := range lines {
-		// " | " splits list and number of sorts
+		// " | " splits list, number of sorts, which sort and whether
+		// to show counts
 		argStrs := strings.Split(line, " | ")
-
-		nums, err := strsToInts(strings.Split(argStrs[0], " "))
-		if err != nil {
-			return outLines, err
+		if len(argStrs) < 2 || len(argStrs) > 4 {
+			return outLines, fmt.Errorf("Cannot parse %#v", line)
 		}
 
-		max, err := strconv.Atoi(argStrs[1])
+		out, err := sortAnyLine(argStrs[0], argStrs[1:])
 		if err != nil {
 			return outLines, err
 		}
-
-		bubbleSortMax(nums, max)
-
-		outLines[i] = strings.Join(intsToStrs(nums), " ")
+		outLines[i] = out
 	}
 
 	return strings.Join(outLines, "\n"), nil
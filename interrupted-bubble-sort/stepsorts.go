@@ -0,0 +1,349 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LimitType is what the max given to a step-limited sort counts.
+type LimitType int
+
+const (
+	// Stop after max passes.  What a pass is depends on the sort, but it
+	// is always one trip of the outer loop.
+	LIMIT_PASSES LimitType = iota
+	// Stop after max swaps, even in the middle of a pass.
+	LIMIT_SWAPS
+)
+
+// stepper wraps the data being sorted and keeps track of how many passes and
+// swaps have been used.  The sorts below only touch the data through it, so
+// they all stop the same way.
+type stepper struct {
+	data sort.Interface
+	lt   LimitType
+	max  int
+	Counts
+	// Everything the sort does is recorded here, unless it is nil
+	trace *Trace
+}
+
+// funcSlice is a slice sorted by a comparison func, for the stepper.
+type funcSlice[T any] struct {
+	s   []T
+	cmp func(a, b T) int
+}
+
+func (fs funcSlice[T]) Len() int           { return len(fs.s) }
+func (fs funcSlice[T]) Less(i, j int) bool { return fs.cmp(fs.s[i], fs.s[j]) < 0 }
+func (fs funcSlice[T]) Swap(i, j int)      { fs.s[i], fs.s[j] = fs.s[j], fs.s[i] }
+
+// Counts is how much work a sort did.
+type Counts struct {
+	Comparisons, Swaps, Passes int
+}
+
+// used returns how much of the limit was actually used.
+func (c Counts) used(lt LimitType) int {
+	if lt == LIMIT_SWAPS {
+		return c.Swaps
+	}
+	return c.Passes
+}
+
+// less reports whether element i belongs before element j.
+func (s *stepper) less(i, j int) bool {
+	s.trace.record(EVENT_COMPARE, i, j)
+	s.Comparisons++
+	return s.data.Less(i, j)
+}
+
+// swap swaps elements i and j.  It returns false, without swapping, if the
+// swap limit has been reached.
+func (s *stepper) swap(i, j int) bool {
+	if s.lt == LIMIT_SWAPS && s.Swaps >= s.max {
+		return false
+	}
+	s.trace.record(EVENT_SWAP, i, j)
+	s.data.Swap(i, j)
+	s.Swaps++
+	return true
+}
+
+// endPass counts a finished pass.
+func (s *stepper) endPass() {
+	s.trace.record(EVENT_PASS, -1, -1)
+	s.Passes++
+}
+
+// done reports whether the limit has been reached.
+func (s *stepper) done() bool {
+	return s.used() >= s.max
+}
+
+// used returns how much of the limit was actually used.
+func (s *stepper) used() int {
+	return s.Counts.used(s.lt)
+}
+
+// bubbleSteps is bubbleSortMax on a stepper.  A pass is one sweep left to
+// right, and it stops after a sweep with nothing to swap.
+func bubbleSteps(s *stepper) {
+	n := s.data.Len()
+	if n < 2 {
+		return
+	}
+	for !s.done() {
+		isChanged := false
+		for i := 0; i+1 < n; i++ {
+			if s.less(i+1, i) {
+				if !s.swap(i, i+1) {
+					return
+				}
+				isChanged = true
+			}
+		}
+		s.endPass()
+		if !isChanged {
+			return
+		}
+	}
+}
+
+// insertionSteps is insertion sort.  A pass inserts the next element into
+// the sorted part on the left by swapping it down.
+func insertionSteps(s *stepper) {
+	n := s.data.Len()
+	for i := 1; i < n && !s.done(); i++ {
+		for j := i; j > 0 && s.less(j, j-1); j-- {
+			if !s.swap(j-1, j) {
+				return
+			}
+		}
+		s.endPass()
+	}
+}
+
+// selectionSteps is selection sort.  A pass finds the smallest remaining
+// element and swaps it into place.
+func selectionSteps(s *stepper) {
+	n := s.data.Len()
+	for i := 0; i+1 < n && !s.done(); i++ {
+		least := i
+		for j := i + 1; j < n; j++ {
+			if s.less(j, least) {
+				least = j
+			}
+		}
+		if least != i && !s.swap(i, least) {
+			return
+		}
+		s.endPass()
+	}
+}
+
+// cocktailSteps is cocktail shaker sort, bubble sort that alternates
+// direction.  A pass is a sweep in either direction, and it stops after a
+// sweep with nothing to swap.
+func cocktailSteps(s *stepper) {
+	lo, hi := 0, s.data.Len()-1
+	for lo < hi && !s.done() {
+		isChanged := false
+		if s.Passes%2 == 0 {
+			// Left to right carries the largest up to hi
+			for i := lo; i < hi; i++ {
+				if s.less(i+1, i) {
+					if !s.swap(i, i+1) {
+						return
+					}
+					isChanged = true
+				}
+			}
+			hi--
+		} else {
+			// Right to left carries the smallest down to lo
+			for i := hi; i > lo; i-- {
+				if s.less(i, i-1) {
+					if !s.swap(i-1, i) {
+						return
+					}
+					isChanged = true
+				}
+			}
+			lo++
+		}
+		s.endPass()
+		if !isChanged {
+			return
+		}
+	}
+}
+
+// combSteps is comb sort, bubble sort comparing elements gap apart, with the
+// gap shrinking by 1.3 each pass.  Once the gap is 1, it stops after a pass
+// with nothing to swap.
+func combSteps(s *stepper) {
+	n := s.data.Len()
+	gap := n
+	for n > 1 && !s.done() {
+		gap = max(gap*10/13, 1)
+		isChanged := false
+		for i := 0; i+gap < n; i++ {
+			if s.less(i+gap, i) {
+				if !s.swap(i, i+gap) {
+					return
+				}
+				isChanged = true
+			}
+		}
+		s.endPass()
+		if gap == 1 && !isChanged {
+			return
+		}
+	}
+}
+
+// shellSteps is Shell sort with Shell's original gaps (n/2, n/4, ..., 1).
+// A pass is an insertion sort of the elements gap apart.
+func shellSteps(s *stepper) {
+	n := s.data.Len()
+	for gap := n / 2; gap > 0 && !s.done(); gap /= 2 {
+		for i := gap; i < n; i++ {
+			for j := i; j >= gap && s.less(j, j-gap); j -= gap {
+				if !s.swap(j-gap, j) {
+					return
+				}
+			}
+		}
+		s.endPass()
+	}
+}
+
+// oddEvenSteps is odd-even transposition sort.  A pass compares the pairs
+// starting at odd indices, then the pairs starting at even indices, and it
+// stops after a pass with nothing to swap.
+func oddEvenSteps(s *stepper) {
+	n := s.data.Len()
+	for n > 1 && !s.done() {
+		isChanged := false
+		for _, start := range []int{1, 0} {
+			for i := start; i+1 < n; i += 2 {
+				if s.less(i+1, i) {
+					if !s.swap(i, i+1) {
+						return
+					}
+					isChanged = true
+				}
+			}
+		}
+		s.endPass()
+		if !isChanged {
+			return
+		}
+	}
+}
+
+// gnomeSteps is gnome sort.  The gnome steps forward while things are in
+// order, and swaps and steps back when they aren't.  A pass ends each time
+// it gets further than it has been before.
+func gnomeSteps(s *stepper) {
+	n := s.data.Len()
+	pos, furthest := 1, 1
+	for pos < n && !s.done() {
+		if pos == 0 || !s.less(pos, pos-1) {
+			pos++
+			if pos > furthest {
+				furthest = pos
+				s.endPass()
+			}
+		} else {
+			if !s.swap(pos-1, pos) {
+				return
+			}
+			pos--
+		}
+	}
+}
+
+// stepSorts are the sorts that can be picked from the input line.
+//
+// bubble, insertion, cocktail, oddeven and gnome only ever swap neighbours
+// that are strictly out of order, so they are stable: equal values keep
+// the order they started in, however early they are stopped.  selection,
+// comb and shell swap elements further apart and aren't.
+var stepSorts = map[string]func(*stepper){
+	"bubble":    bubbleSteps,
+	"insertion": insertionSteps,
+	"selection": selectionSteps,
+	"cocktail":  cocktailSteps,
+	"comb":      combSteps,
+	"shell":     shellSteps,
+	"oddeven":   oddEvenSteps,
+	"gnome":     gnomeSteps,
+}
+
+// sortMax applies the named sort to nums in place, limited to max passes or
+// swaps.  Like bubbleSortMax, it returns how many were actually used.
+func sortMax(name string, nums []int, max int, lt LimitType) (int, error) {
+	counts, err := SortMaxFunc(name, nums, max, lt, cmp.Compare[int])
+	return counts.used(lt), err
+}
+
+// SortMaxFunc applies the named sort to s in place, ordered by cmp and
+// limited to max passes or swaps.  It returns all of the counts.
+func SortMaxFunc[T any](name string, s []T, max int, lt LimitType,
+	cmp func(a, b T) int) (Counts, error) {
+	steps, ok := stepSorts[name]
+	if !ok {
+		return Counts{}, fmt.Errorf("Unknown sort %#v", name)
+	}
+	// There is a shortcut for bubble sort by passes
+	if name == "bubble" && lt == LIMIT_PASSES {
+		return bubbleSortPassesCounts(s, max, cmp), nil
+	}
+
+	st := stepper{data: funcSlice[T]{s, cmp}, lt: lt, max: max}
+	steps(&st)
+	return st.Counts, nil
+}
+
+// sortSpec is which sort to use and how, from the optional third part of an
+// input line.
+type sortSpec struct {
+	name string
+	lt   LimitType
+	// Largest first
+	desc bool
+}
+
+// parseSortSpec parses "<sort> [passes|swaps] [asc|desc]".  An empty spec
+// is bubble sort by passes, smallest first.
+func parseSortSpec(spec string) (sortSpec, error) {
+	fields := strings.Fields(spec)
+	ss := sortSpec{name: "bubble", lt: LIMIT_PASSES}
+	if len(fields) > 0 {
+		ss.name = fields[0]
+	}
+	if len(fields) > 3 {
+		return sortSpec{}, fmt.Errorf("Cannot parse sort %#v", spec)
+	}
+	for i, field := range fields[min(len(fields), 1):] {
+		// The direction can only come last, so there is at most one
+		last := i == len(fields)-2
+		switch {
+		case i == 0 && field == "passes":
+			ss.lt = LIMIT_PASSES
+		case i == 0 && field == "swaps":
+			ss.lt = LIMIT_SWAPS
+		case last && field == "asc":
+			ss.desc = false
+		case last && field == "desc":
+			ss.desc = true
+		default:
+			return sortSpec{}, fmt.Errorf("Unknown limit %#v", field)
+		}
+	}
+	return ss, nil
+}
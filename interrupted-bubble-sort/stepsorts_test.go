@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestSortMax(t *testing.T) {
+	type Args struct {
+		name string
+		max  int
+		lt   LimitType
+	}
+	type Pair struct {
+		input         Args
+		expected      []int
+		expectedCount int
+	}
+
+	pairs := []Pair{
+		{Args{"bubble", 3, LIMIT_SWAPS}, []int{1, 4, 2, 5, 3}, 3},
+		{Args{"insertion", 2, LIMIT_PASSES}, []int{1, 4, 5, 2, 3}, 2},
+		{Args{"insertion", 100, LIMIT_PASSES}, []int{1, 2, 3, 4, 5}, 4},
+		{Args{"selection", 2, LIMIT_PASSES}, []int{1, 2, 4, 5, 3}, 2},
+		{Args{"cocktail", 2, LIMIT_PASSES}, []int{1, 2, 4, 3, 5}, 2},
+		{Args{"comb", 1, LIMIT_PASSES}, []int{2, 1, 4, 5, 3}, 1},
+		{Args{"shell", 1, LIMIT_PASSES}, []int{3, 1, 4, 2, 5}, 1},
+		{Args{"shell", 100, LIMIT_PASSES}, []int{1, 2, 3, 4, 5}, 2},
+		{Args{"oddeven", 1, LIMIT_PASSES}, []int{1, 5, 2, 4, 3}, 1},
+		{Args{"gnome", 2, LIMIT_PASSES}, []int{1, 4, 5, 2, 3}, 2},
+		{Args{"gnome", 0, LIMIT_PASSES}, []int{5, 1, 4, 2, 3}, 0},
+	}
+	for _, p := range pairs {
+		nums := []int{5, 1, 4, 2, 3}
+		count, err := sortMax(p.input.name, nums, p.input.max, p.input.lt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(p.expected) != fmt.Sprint(nums) ||
+			p.expectedCount != count {
+			t.Fatalf("Input: %#v\nExpected: %v (%v)\n     Got: %v (%v)\n",
+				p.input, p.expected, p.expectedCount, nums, count)
+		}
+	}
+
+	if _, err := sortMax("bogo", []int{2, 1}, 1, LIMIT_PASSES); err == nil {
+		t.Fatal("Expected an error for an unknown sort")
+	}
+}
+
+func TestStepSortsRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 300; trial++ {
+		nums := make([]int, rng.Intn(30))
+		for i := range nums {
+			nums[i] = rng.Intn(15)
+		}
+
+		for name, steps := range stepSorts {
+			// Enough passes for anything to finish
+			result := slices.Clone(nums)
+			steps(&stepper{data: sort.IntSlice(result),
+				lt: LIMIT_PASSES, max: 1 << 30})
+			if !slices.IsSorted(result) {
+				t.Fatalf("%v: Input: %v\nGot: %v\n", name, nums, result)
+			}
+
+			// Swapping neighbours fixes exactly one inversion
+			// at a time, so these all take that many swaps
+			switch name {
+			case "bubble", "insertion", "cocktail", "oddeven", "gnome":
+				s := stepper{data: sort.IntSlice(slices.Clone(nums)),
+					lt: LIMIT_SWAPS, max: 1 << 30}
+				steps(&s)
+				if s.used() != slowInversions(nums) {
+					t.Fatalf("%v: Input: %v\nExpected %v swaps, "+
+						"got %v\n", name, nums,
+						slowInversions(nums), s.used())
+				}
+			}
+
+			// Running out of swaps stops right there
+			limit := rng.Intn(10)
+			s := stepper{data: sort.IntSlice(slices.Clone(nums)),
+				lt: LIMIT_SWAPS, max: limit}
+			steps(&s)
+			if s.used() > limit {
+				t.Fatalf("%v: Input: %v\nUsed %v swaps of %v\n",
+					name, nums, s.used(), limit)
+			}
+		}
+
+		// The stepper version of bubble sort is the same as the
+		// original
+		max := rng.Intn(35)
+		expected := slices.Clone(nums)
+		expectedCounts := bubbleSortMax(expected, max)
+		s := stepper{data: sort.IntSlice(slices.Clone(nums)),
+			lt: LIMIT_PASSES, max: max}
+		bubbleSteps(&s)
+		result := []int(s.data.(sort.IntSlice))
+		if !slices.Equal(expected, result) || expectedCounts != s.Counts {
+			t.Fatalf("Input: %v | %v\nExpected: %v (%+v)\n     Got: %v (%+v)\n",
+				nums, max, expected, expectedCounts, result, s.Counts)
+		}
+	}
+}
+
+func TestParseSortSpec(t *testing.T) {
+	type Pair struct {
+		input    string
+		expected sortSpec
+	}
+
+	pairs := []Pair{
+		{"", sortSpec{"bubble", LIMIT_PASSES, false}},
+		{"shell", sortSpec{"shell", LIMIT_PASSES, false}},
+		{"gnome swaps", sortSpec{"gnome", LIMIT_SWAPS, false}},
+		{"comb desc", sortSpec{"comb", LIMIT_PASSES, true}},
+		{"bubble swaps desc", sortSpec{"bubble", LIMIT_SWAPS, true}},
+		{"bubble passes asc", sortSpec{"bubble", LIMIT_PASSES, false}},
+	}
+	for _, p := range pairs {
+		result, err := parseSortSpec(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for _, input := range []string{"gnome steps", "gnome swaps now",
+		"gnome desc swaps", "gnome swaps desc asc", "gnome desc asc",
+		"gnome asc desc"} {
+		if _, err := parseSortSpec(input); err == nil {
+			t.Fatalf("Expected an error for %#v", input)
+		}
+	}
+}
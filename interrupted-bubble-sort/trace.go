@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventKind is the kind of thing a sort did.
+type EventKind int
+
+const (
+	// Two elements were compared
+	EVENT_COMPARE EventKind = iota
+	// Two elements were swapped
+	EVENT_SWAP
+	// A pass finished.  I and J are unused.
+	EVENT_PASS
+)
+
+// Event is one step of a sort, on the elements at indices I and J.
+type Event struct {
+	Kind EventKind
+	I, J int
+}
+
+// Trace is everything a sort did, from the values it started with.  Only
+// the indices are recorded, so the values can be replayed to see the state
+// at any point.
+type Trace struct {
+	// Initial is the rank of each value the sort started with, by the
+	// sort's own order, the first 0 and equal values sharing one.  That's
+	// all drawing them needs, whatever type they are.
+	Initial []int
+	// Labels are the values the sort started with, as text
+	Labels []string
+	Events []Event
+}
+
+// record adds an event to the trace.  A nil trace records nothing, which is
+// how tracing is left off.
+func (tr *Trace) record(kind EventKind, i, j int) {
+	if tr != nil {
+		tr.Events = append(tr.Events, Event{kind, i, j})
+	}
+}
+
+// Frame is the state of the values right after an event.
+type Frame struct {
+	Event
+	// Ranks and labels of the values, in their order at this point
+	Values []int
+	Labels []string
+	// Passes finished so far, including this event if it is EVENT_PASS
+	Pass int
+}
+
+// Frames replays the trace, returning a frame per event.  The first frame
+// is the initial state, with the EVENT_PASS kind and I and J set to -1 so
+// nothing is highlighted.
+func (tr *Trace) Frames() []Frame {
+	// order is which initial value is at each index
+	order := make([]int, len(tr.Initial))
+	for i := range order {
+		order[i] = i
+	}
+	frame := func(e Event, pass int) Frame {
+		f := Frame{e, make([]int, len(order)), make([]string, len(order)),
+			pass}
+		for i, k := range order {
+			f.Values[i] = tr.Initial[k]
+			if k < len(tr.Labels) {
+				f.Labels[i] = tr.Labels[k]
+			}
+		}
+		return f
+	}
+
+	frames := make([]Frame, 0, len(tr.Events)+1)
+	frames = append(frames, frame(Event{EVENT_PASS, -1, -1}, 0))
+	pass := 0
+	for _, e := range tr.Events {
+		switch e.Kind {
+		case EVENT_SWAP:
+			order[e.I], order[e.J] = order[e.J], order[e.I]
+		case EVENT_PASS:
+			pass++
+		}
+		frames = append(frames, frame(e, pass))
+	}
+	return frames
+}
+
+// Describe says what happened in the frame, e.g. "pass 1: swap 3 and 4".
+func (f Frame) Describe() string {
+	switch {
+	case f.Kind == EVENT_PASS && f.Pass == 0:
+		return "start"
+	case f.Kind == EVENT_COMPARE:
+		return fmt.Sprintf("pass %d: compare %d and %d", f.Pass+1, f.I, f.J)
+	case f.Kind == EVENT_SWAP:
+		return fmt.Sprintf("pass %d: swap %d and %d", f.Pass+1, f.I, f.J)
+	}
+	return fmt.Sprintf("end of pass %d", f.Pass)
+}
+
+// ranks returns the rank of each value in s, ordered by cmp, with equal
+// values sharing one.
+func ranks[T any](s []T, cmp func(a, b T) int) []int {
+	order := make([]int, len(s))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp(s[i], s[j]) })
+	rv := make([]int, len(s))
+	for k := 1; k < len(order); k++ {
+		prev, i := order[k-1], order[k]
+		rv[i] = rv[prev]
+		if cmp(s[prev], s[i]) != 0 {
+			rv[i]++
+		}
+	}
+	return rv
+}
+
+// traceSort is SortMaxFunc, but it also returns a trace of everything the
+// sort did.  Tracing is opt-in since it keeps every comparison in memory,
+// and it always runs the sort for real (there's no shortcut to trace).
+func traceSort[T any](name string, s []T, max int, lt LimitType,
+	cmp func(a, b T) int) (*Trace, Counts, error) {
+	steps, ok := stepSorts[name]
+	if !ok {
+		return nil, Counts{}, fmt.Errorf("Unknown sort %#v", name)
+	}
+
+	tr := &Trace{Initial: ranks(s, cmp), Labels: make([]string, len(s))}
+	for i, v := range s {
+		tr.Labels[i] = fmt.Sprint(v)
+	}
+	st := stepper{data: funcSlice[T]{s, cmp}, lt: lt, max: max, trace: tr}
+	steps(&st)
+	return tr, st.Counts, nil
+}
+
+// TRACE_FRAME_DELAY is how long each frame of a trace is shown.
+const TRACE_FRAME_DELAY = 200 * time.Millisecond
+
+// Where "trace term" animates to, and how long each frame of a trace is
+// shown.  The animation goes to stderr so it stays out of the answers on
+// stdout.  Tests swap these out.
+var (
+	traceOut   io.Writer = os.Stderr
+	traceDelay           = TRACE_FRAME_DELAY
+)
+
+// writeTrace shows or saves tr in the way opt asks for, where opt is what
+// follows "trace" on an input line.  "term" animates tr in the terminal,
+// and "svg <file>" or "gif <file>" write it to the file as an animation.
+func writeTrace(tr *Trace, opt []string) error {
+	switch {
+	case len(opt) == 1 && opt[0] == "term":
+		return tr.Animate(traceOut, traceDelay)
+	case len(opt) == 2 && (opt[0] == "svg" || opt[0] == "gif"):
+		write := tr.WriteSVG
+		if opt[0] == "gif" {
+			write = tr.WriteGIF
+		}
+		f, err := os.Create(opt[1])
+		if err != nil {
+			return err
+		}
+		if err := write(f, traceDelay); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	return fmt.Errorf("Cannot parse trace %#v", strings.Join(opt, " "))
+}
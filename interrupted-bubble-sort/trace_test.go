@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTraceSort(t *testing.T) {
+	nums := []int{3, 1, 2}
+	tr, counts, err := traceSort("bubble", nums, 10, LIMIT_PASSES,
+		cmp.Compare[int])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pass 1 swaps 3 to the end, pass 2 finds nothing to swap
+	expected := []Event{
+		{EVENT_COMPARE, 1, 0}, {EVENT_SWAP, 0, 1},
+		{EVENT_COMPARE, 2, 1}, {EVENT_SWAP, 1, 2},
+		{EVENT_PASS, -1, -1},
+		{EVENT_COMPARE, 1, 0}, {EVENT_COMPARE, 2, 1},
+		{EVENT_PASS, -1, -1},
+	}
+	if fmt.Sprint(expected) != fmt.Sprint(tr.Events) || counts.Passes != 2 {
+		t.Fatalf("Expected: %v (2)\n     Got: %v (%v)\n",
+			expected, tr.Events, counts.Passes)
+	}
+	if fmt.Sprint(tr.Initial) != "[2 0 1]" ||
+		fmt.Sprint(tr.Labels) != "[3 1 2]" {
+		t.Fatalf("Expected the initial ranks and values, got %v %v",
+			tr.Initial, tr.Labels)
+	}
+
+	if _, _, err := traceSort("bogo", nums, 1, LIMIT_PASSES,
+		cmp.Compare[int]); err == nil {
+		t.Fatal("Expected an error for an unknown sort")
+	}
+}
+
+func TestTraceFrames(t *testing.T) {
+	for name := range stepSorts {
+		nums := []int{5, 1, 4, 2, 3}
+		tr, _, err := traceSort(name, nums, 2, LIMIT_PASSES,
+			cmp.Compare[int])
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames := tr.Frames()
+		if len(frames) != len(tr.Events)+1 {
+			t.Fatalf("%v: expected %v frames, got %v",
+				name, len(tr.Events)+1, len(frames))
+		}
+		// Replaying ends up where the sort did
+		last := frames[len(frames)-1]
+		if fmt.Sprint(nums) != fmt.Sprint(last.Labels) {
+			t.Fatalf("%v: expected %v, got %v", name, nums, last.Labels)
+		}
+		if !slices.Equal(ranks(nums, cmp.Compare[int]), last.Values) {
+			t.Fatalf("%v: expected the ranks of %v, got %v",
+				name, nums, last.Values)
+		}
+		if last.Pass != 2 {
+			t.Fatalf("%v: expected 2 passes, got %v", name, last.Pass)
+		}
+	}
+}
+
+func TestFrameDescribe(t *testing.T) {
+	type Pair struct {
+		input    Frame
+		expected string
+	}
+
+	pairs := []Pair{
+		{Frame{Event{EVENT_PASS, -1, -1}, nil, nil, 0}, "start"},
+		{Frame{Event{EVENT_COMPARE, 1, 0}, nil, nil, 0},
+			"pass 1: compare 1 and 0"},
+		{Frame{Event{EVENT_SWAP, 0, 1}, nil, nil, 1},
+			"pass 2: swap 0 and 1"},
+		{Frame{Event{EVENT_PASS, -1, -1}, nil, nil, 2}, "end of pass 2"},
+	}
+	for _, p := range pairs {
+		result := p.input.Describe()
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestRanks(t *testing.T) {
+	type Pair struct {
+		input, expected []int
+	}
+
+	pairs := []Pair{
+		{[]int{}, []int{}},
+		{[]int{5, 1, 4, 2, 3}, []int{4, 0, 3, 1, 2}},
+		// Equal values share a rank, and the next one follows on
+		{[]int{7, 3, 7, 3, 9}, []int{1, 0, 1, 0, 2}},
+	}
+	for _, p := range pairs {
+		result := ranks(p.input, cmp.Compare[int])
+		if !slices.Equal(p.expected, result) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestTraceLines(t *testing.T) {
+	var buf bytes.Buffer
+	traceOut, traceDelay = &buf, 0
+	defer func() { traceOut, traceDelay = os.Stderr, TRACE_FRAME_DELAY }()
+
+	// Strings trace as well as ints, and the answer is the same as
+	// without the trace
+	result, err := InterruptedBubbleSort([]byte(
+		"c a b | 1 | bubble | trace term"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "a b c" {
+		t.Fatalf("Expected %#v, got %#v", "a b c", result)
+	}
+	if !strings.Contains(buf.String(), "     c "+ANSI_RED) {
+		t.Fatalf("Expected c to be drawn swapping, got %#v", buf.String())
+	}
+
+	dir := t.TempDir()
+	for _, format := range []string{"svg", "gif"} {
+		path := filepath.Join(dir, "trace."+format)
+		line := "2.5 1.5 | 2 | insertion | trace " + format + " " + path
+		result, err := InterruptedBubbleSort([]byte(line))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != "1.5 2.5" {
+			t.Fatalf("Input: %#v\nExpected %#v, got %#v", line,
+				"1.5 2.5", result)
+		}
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Fatalf("Input: %#v\nExpected a file, got %v", line, err)
+		}
+	}
+
+	for _, line := range []string{"3 1 | 1 | bubble | trace",
+		"3 1 | 1 | bubble | trace png x.png", "3 1 | 1 | bubble | trace svg",
+		"3 1 | 1 | bubble | trace svg " + filepath.Join(dir, "no", "x.svg")} {
+		if _, err := InterruptedBubbleSort([]byte(line)); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", line)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/algebra"
+	"github.com/carbonizer/codeeval-go/table"
+)
+
+// CAYLEY_BUILDERS make the tables a cayley line can ask for with just a
+// number, by name.
+var CAYLEY_BUILDERS = map[string]func(int) (*algebra.Cayley, error){
+	"add":   algebra.AdditiveMod,
+	"mul":   algebra.MultiplicativeMod,
+	"units": algebra.UnitsMod,
+	"sym":   algebra.Symmetric,
+}
+
+// parseCayley parses a line like "cayley add 5" into its table.  After
+// "cayley" comes one of
+//
+//	add N, mul N or units N     arithmetic mod N (units: coprime to N)
+//	sym N                       every permutation of N points
+//	perm N (12) (1234)          the permutations of N points generated
+//	table e a b : e a b / a b e / b e a
+//	                            elements, then the rows of products
+//
+// Generators are separated by spaces outside the parentheses, so
+// "(12)(34)" is one generator and "(12) (34)" is two.  Points within a
+// cycle can be separated by spaces or commas, as in "(1 10)".
+func parseCayley(line string) (*algebra.Cayley, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[0] != "cayley" {
+		return nil, fmt.Errorf("Cannot parse %#v", line)
+	}
+	kind, args := fields[1], fields[2:]
+
+	if kind == "table" {
+		elements, rows, found := strings.Cut(strings.Join(args, " "), ":")
+		if !found {
+			return nil, fmt.Errorf("Cannot parse %#v, expected elements "+
+				"then \":\" and the rows", line)
+		}
+		cells := [][]string{}
+		for _, row := range strings.Split(rows, "/") {
+			cells = append(cells, strings.Fields(row))
+		}
+		return algebra.New("*", strings.Fields(elements), cells)
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse %#v", line)
+	}
+	if kind == "perm" {
+		gens := []algebra.Perm{}
+		for _, arg := range splitGenerators(strings.Join(args[1:], " ")) {
+			g, err := algebra.ParsePerm(n, arg)
+			if err != nil {
+				return nil, err
+			}
+			gens = append(gens, g)
+		}
+		return algebra.Generated(n, gens)
+	}
+	fn, ok := CAYLEY_BUILDERS[kind]
+	if !ok || len(args) != 1 {
+		return nil, fmt.Errorf("Cannot parse %#v", line)
+	}
+	return fn(n)
+}
+
+// splitGenerators splits s at the spaces that aren't inside a cycle.
+func splitGenerators(s string) []string {
+	gens, start, inCycle := []string{}, -1, false
+	for i, r := range s {
+		switch {
+		case r == '(':
+			inCycle = true
+		case r == ')':
+			inCycle = false
+		case r == ' ' && !inCycle:
+			if start >= 0 {
+				gens = append(gens, s[start:i])
+			}
+			start = -1
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	// Including a cycle that's never closed, for ParsePerm to refuse
+	if start >= 0 {
+		gens = append(gens, s[start:])
+	}
+	return gens
+}
+
+// cayleyTable renders the table for a cayley line with renderer, followed
+// by what Analyze found out about it.
+func cayleyTable(line string) (string, error) {
+	c, err := parseCayley(line)
+	if err != nil {
+		return "", err
+	}
+	out, err := table.String(renderer, c.Table())
+	if err != nil {
+		return "", err
+	}
+	return out + "\n\n" + c.Analyze().String(), nil
+}
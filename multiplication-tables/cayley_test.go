@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCayleyTables(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"cayley add 2", strings.Join([]string{
+			"+ | 0 1",
+			"--+----",
+			"0 | 0 1",
+			"1 | 1 0",
+			"",
+			"order 2",
+			"closed yes",
+			"associative yes",
+			"identity 0",
+			"inverses 0:0 1:1",
+			"commutative yes",
+			"group yes, abelian",
+			"element orders 0:1 1:2",
+		}, "\n")},
+		{"cayley table e a : e a / a a", strings.Join([]string{
+			"* | e a",
+			"--+----",
+			"e | e a",
+			"a | a a",
+			"",
+			"order 2",
+			"closed yes",
+			"associative yes",
+			"identity e",
+			"inverses e:e a:none",
+			"commutative yes",
+			"group no",
+			"element orders e:1 a:none",
+		}, "\n")},
+		{"cayley perm 3 (123)", strings.Join([]string{
+			"    o |     e (123) (132)",
+			"------+------------------",
+			"    e |     e (123) (132)",
+			"(123) | (123) (132)     e",
+			"(132) | (132)     e (123)",
+			"",
+			"order 3",
+			"closed yes",
+			"associative yes",
+			"identity e",
+			"inverses e:e (123):(132) (132):(123)",
+			"commutative yes",
+			"group yes, abelian",
+			"element orders e:1 (123):3 (132):3",
+		}, "\n")},
+	}
+	for _, p := range pairs {
+		got, err := MultiplicationTables([]byte(p.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, got)
+		}
+	}
+
+	// Every kind of table parses
+	for _, line := range []string{"cayley mul 6", "cayley units 9",
+		"cayley sym 4", "cayley perm 4 (12)(34) (1,3)"} {
+		if _, err := parseCayley(line); err != nil {
+			t.Fatalf("Input: %#v\nError: %v\n", line, err)
+		}
+	}
+
+	// Spaces inside a cycle don't split the generator
+	type OrderPair struct {
+		input    string
+		expected int
+	}
+	orderPairs := []OrderPair{
+		{"cayley perm 10 (1 10)", 2},
+		{"cayley perm 10 (1 10)(2 3)", 2},
+		{"cayley perm 4 (1 2) (3 4)", 4},
+		{"cayley perm 4 (12)(34)", 2},
+	}
+	for _, p := range orderPairs {
+		c, err := parseCayley(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Order() != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, c.Order())
+		}
+	}
+	for _, line := range []string{"cayley", "cayley add", "cayley add x",
+		"cayley add 3 4", "cayley div 3", "cayley sym 6", "cayley perm 3 (14)",
+		"cayley perm -1 e", "cayley perm 0 e", "cayley perm 2000000000 e",
+		"cayley perm 10 (1 10", "cayley perm 10 (1 1 0)",
+		"cayley table e a / e a / a e", "cayley table e a : e a"} {
+		if _, err := parseCayley(line); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", line)
+		}
+	}
+}
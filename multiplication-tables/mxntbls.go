@@ -2,6 +2,7 @@ package main
 
 // Common imports for main and handling input
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -12,6 +13,8 @@ import (
 import (
 	"strconv"
 	"strings"
+
+	"github.com/carbonizer/codeeval-go/table"
 )
 
 //
@@ -34,9 +37,10 @@ func inputToFuncToStdout(fn func([]byte) (interface{}, error), it InputType) {
 	// Input via stdin
 	case INPUT_STDIN:
 		fp = os.Stdin
-	// Input from the file at the part of the first command argument
+	// Input from the file at the path of the first argument after the
+	// flags
 	case INPUT_FILEARG:
-		fp, err = os.Open(os.Args[1])
+		fp, err = os.Open(flag.Arg(0))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -76,20 +80,24 @@ const FAKE_INPUT = "12"
 // Custom code for this problem
 //
 
+// renderer renders general tables, and the original one too if it isn't
+// table.Text.  It's picked with -format.
+var renderer table.Renderer = table.Text{}
+
 // Just update the function name and the input type
 func main() {
-	inputToFuncToStdout(MultiplicationTables, INPUT_CONSTANT)
-}
-
-func MultiplicationTables(stdin []byte) (interface{}, error) {
-	n, err := strconv.Atoi(string(stdin))
-
-	if err != nil {
-		fmt.Println()
-		return "", fmt.Errorf(
-			"Cannot convert %#v to number\n", string(stdin))
+	format := flag.String("format", "text", "how to render tables, one of "+
+		strings.Join(table.Names(), ", "))
+	flag.Parse()
+	var err error
+	if renderer, err = table.Lookup(*format); err != nil {
+		log.Fatal(err)
 	}
+	inputToFuncToStdout(MultiplicationTables, INPUT_FILEARG)
+}
 
+// codeEvalTable is the original problem's n by n multiplication table.
+func codeEvalTable(n int) string {
 	lines := make([]string, n)
 	chunks := make([]string, n)
 	for i, _ := range lines {
@@ -102,6 +110,50 @@ func MultiplicationTables(stdin []byte) (interface{}, error) {
 		// so I did it.
 		lines[i] = strings.TrimLeft(strings.Join(chunks, ""), " ")
 	}
+	return strings.Join(lines, "\n")
+}
 
-	return strings.Join(lines, "\n"), nil
+// MultiplicationTables makes a table for each line of input.  A bare number
+// n is the original problem, n by n with %4d columns.  A line starting
+// "cayley" is a Cayley table followed by a report on its structure (see
+// parseCayley).  Anything else is a spec for a general table, like
+// "* 1..12 1..12 header" (see parseSpec).  Tables are separated by blank
+// lines, and rendered by renderer.  Other renderers than table.Text render
+// the original problem as "* n" instead.  Empty input is the original
+// problem for 12.
+func MultiplicationTables(stdin []byte) (interface{}, error) {
+	text := strings.TrimSpace(string(stdin))
+	if text == "" {
+		text = FAKE_INPUT
+	}
+	tables := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "cayley") {
+			out, err := cayleyTable(line)
+			if err != nil {
+				return "", err
+			}
+			tables = append(tables, out)
+			continue
+		}
+		n, err := strconv.Atoi(line)
+		if _, isText := renderer.(table.Text); err == nil && isText {
+			tables = append(tables, codeEvalTable(n))
+			continue
+		}
+		if err == nil {
+			line = "* " + line
+		}
+		spec, err := parseSpec(line)
+		if err != nil {
+			return "", err
+		}
+		out, err := table.String(renderer, spec.table())
+		if err != nil {
+			return "", err
+		}
+		tables = append(tables, out)
+	}
+	return strings.Join(tables, "\n\n"), nil
 }
@@ -23,8 +23,22 @@ func TestMultiplicationTables(t *testing.T) {
 			t.Fatal(err)
 		}
 		if p.expected != result {
-			fmt.Println("Input: %#v", p.input)
+			fmt.Printf("Input: %#v\n", p.input)
 			t.Fatalf("Expected %#v, got %#v", p.expected, result)
 		}
 	}
 }
+
+func TestMultiplicationTablesEmpty(t *testing.T) {
+	expected, _ := MultiplicationTables([]byte(FAKE_INPUT))
+	for _, input := range []string{"", "\n \n"} {
+		result, err := MultiplicationTables([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected != result {
+			fmt.Printf("Input: %#v\n", input)
+			t.Fatalf("Expected %#v, got %#v", expected, result)
+		}
+	}
+}
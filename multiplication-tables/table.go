@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/table"
+)
+
+// errUndefined is an op's answer for operands it has no int64 answer for,
+// like mod 0 or an overflow.
+var errUndefined = errors.New("undefined")
+
+// UNDEFINED is what a table shows where its op is undefined.
+const UNDEFINED = "-"
+
+// Op is a binary operation a table can show.
+type Op struct {
+	Symbol string
+	Fn     func(a, b int64) (int64, error)
+}
+
+func add(a, b int64) (int64, error) {
+	sum := a + b
+	// Overflow iff both have the same sign and the sum doesn't
+	if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+		return 0, errUndefined
+	}
+	return sum, nil
+}
+
+func sub(a, b int64) (int64, error) {
+	if b == math.MinInt64 {
+		if a >= 0 {
+			return 0, errUndefined
+		}
+		return a - b, nil
+	}
+	return add(a, -b)
+}
+
+func mul(a, b int64) (int64, error) {
+	neg := (a < 0) != (b < 0)
+	hi, lo := bits.Mul64(absUint(a), absUint(b))
+	if hi != 0 || (!neg && lo > math.MaxInt64) ||
+		(neg && lo > uint64(math.MaxInt64)+1) {
+		return 0, errUndefined
+	}
+	if neg {
+		return -int64(lo), nil
+	}
+	return int64(lo), nil
+}
+
+func absUint(a int64) uint64 {
+	if a < 0 {
+		return uint64(-a)
+	}
+	return uint64(a)
+}
+
+// mod is the remainder with the sign of the divisor, so a row of mod n
+// stays in 0..n-1.
+func mod(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, errUndefined
+	}
+	if b == -1 {
+		return 0, nil
+	}
+	rem := a % b
+	if rem != 0 && (rem < 0) != (b < 0) {
+		rem += b
+	}
+	return rem, nil
+}
+
+func pow(a, b int64) (int64, error) {
+	// These never overflow, and only they have integer reciprocals
+	switch {
+	case a == 1 || b == 0:
+		return 1, nil
+	case a == -1:
+		return 1 - 2*int64(absUint(b)%2), nil
+	case b < 0:
+		return 0, errUndefined
+	case a == 0:
+		return 0, nil
+	}
+	rv := int64(1)
+	for ; b > 0; b-- {
+		var err error
+		if rv, err = mul(rv, a); err != nil {
+			return 0, err
+		}
+	}
+	return rv, nil
+}
+
+func gcd(a, b int64) (int64, error) {
+	x, y := absUint(a), absUint(b)
+	for y != 0 {
+		x, y = y, x%y
+	}
+	if x > math.MaxInt64 {
+		return 0, errUndefined
+	}
+	return int64(x), nil
+}
+
+func xor(a, b int64) (int64, error) {
+	return a ^ b, nil
+}
+
+// OPS are the operations by the names a spec can use.
+var OPS = map[string]Op{
+	"+":   {"+", add},
+	"-":   {"-", sub},
+	"*":   {"*", mul},
+	"x":   {"*", mul},
+	"mod": {"mod", mod},
+	"pow": {"pow", pow},
+	"gcd": {"gcd", gcd},
+	"xor": {"xor", xor},
+}
+
+// BASES are the number bases by the names a spec can use.
+var BASES = map[string]int{"bin": 2, "oct": 8, "dec": 10, "hex": 16}
+
+// tableSpec describes a table of op over rows and columns of operands.
+type tableSpec struct {
+	op Op
+	// Inclusive ranges of the operands down the side and along the top
+	rows, cols [2]int64
+	base       int
+	header     bool
+}
+
+// TABLE_CELLS_MAX caps how big a table can be.
+const TABLE_CELLS_MAX = 1 << 20
+
+// parseRange parses "A..B", or "N" for 1..N.
+func parseRange(field string) ([2]int64, error) {
+	lo, hi, found := strings.Cut(field, "..")
+	if !found {
+		lo, hi = "1", field
+	}
+	a, errA := strconv.ParseInt(lo, 10, 64)
+	b, errB := strconv.ParseInt(hi, 10, 64)
+	if errA != nil || errB != nil || a > b {
+		return [2]int64{}, fmt.Errorf("Cannot parse range %#v", field)
+	}
+	return [2]int64{a, b}, nil
+}
+
+// isRange reports whether field looks like a range rather than an option.
+func isRange(field string) bool {
+	return strings.Contains(field, "..") ||
+		strings.Trim(field, "0123456789") == ""
+}
+
+// parseSpec parses a line like "* 1..12", "pow 0..4 0..10 header" or
+// "xor 0..15 0..15 hex".  After the op come the rows, then optionally the
+// columns (the same as the rows otherwise), a base (bin, oct, dec, hex or
+// "base B") and "header" to label the rows and columns.
+func parseSpec(line string) (tableSpec, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return tableSpec{}, fmt.Errorf("Cannot parse %#v", line)
+	}
+	op, ok := OPS[fields[0]]
+	if !ok {
+		return tableSpec{}, fmt.Errorf("Unknown op %#v", fields[0])
+	}
+	spec := tableSpec{op: op, base: 10}
+	var err error
+	if spec.rows, err = parseRange(fields[1]); err != nil {
+		return tableSpec{}, err
+	}
+	spec.cols = spec.rows
+
+	rest := fields[2:]
+	if len(rest) > 0 && isRange(rest[0]) {
+		if spec.cols, err = parseRange(rest[0]); err != nil {
+			return tableSpec{}, err
+		}
+		rest = rest[1:]
+	}
+	for i := 0; i < len(rest); i++ {
+		switch field := rest[i]; {
+		case field == "header":
+			spec.header = true
+		case BASES[field] != 0:
+			spec.base = BASES[field]
+		case field == "base" && i+1 < len(rest):
+			i++
+			spec.base, err = strconv.Atoi(rest[i])
+			if err != nil || spec.base < 2 || spec.base > 36 {
+				return tableSpec{}, fmt.Errorf("Cannot use base %#v",
+					rest[i])
+			}
+		default:
+			return tableSpec{}, fmt.Errorf("Cannot parse %#v in %#v",
+				field, line)
+		}
+	}
+
+	// Sizes past the cap could overflow, so check them in floats
+	numRows := float64(spec.rows[1]) - float64(spec.rows[0]) + 1
+	numCols := float64(spec.cols[1]) - float64(spec.cols[0]) + 1
+	if numRows*numCols > TABLE_CELLS_MAX {
+		return tableSpec{}, fmt.Errorf("Too big a table: %#v", line)
+	}
+	return spec, nil
+}
+
+// format writes v in the spec's base.
+func (spec tableSpec) format(v int64) string {
+	return strconv.FormatInt(v, spec.base)
+}
+
+// cells works out the table, header row and column included if it has
+// them.
+func (spec tableSpec) cells() [][]string {
+	format := spec.format
+	rv := [][]string{}
+	if spec.header {
+		top := []string{spec.op.Symbol}
+		for b := spec.cols[0]; ; b++ {
+			top = append(top, format(b))
+			if b == spec.cols[1] {
+				break
+			}
+		}
+		rv = append(rv, top)
+	}
+	for a := spec.rows[0]; ; a++ {
+		row := []string{}
+		if spec.header {
+			row = append(row, format(a))
+		}
+		for b := spec.cols[0]; ; b++ {
+			if v, err := spec.op.Fn(a, b); err != nil {
+				row = append(row, UNDEFINED)
+			} else {
+				row = append(row, format(v))
+			}
+			// Checked here, so the range can end at the largest int64
+			if b == spec.cols[1] {
+				break
+			}
+		}
+		rv = append(rv, row)
+		if a == spec.rows[1] {
+			break
+		}
+	}
+	return rv
+}
+
+// table is the spec's table for a renderer, with the values behind the
+// cells for the ones that colour by them.
+func (spec tableSpec) table() *table.Table {
+	cells := spec.cells()
+	values := make([][]float64, len(cells))
+	for i, row := range cells {
+		values[i] = make([]float64, len(row))
+		for j, cell := range row {
+			v, err := strconv.ParseInt(cell, spec.base, 64)
+			if err != nil {
+				values[i][j] = math.NaN()
+			} else {
+				values[i][j] = float64(v)
+			}
+		}
+	}
+	return &table.Table{Cells: cells, HeaderRow: spec.header,
+		HeaderCol: spec.header, Values: values}
+}
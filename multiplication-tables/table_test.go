@@ -0,0 +1,193 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/carbonizer/codeeval-go/table"
+)
+
+func TestOps(t *testing.T) {
+	type Args struct {
+		op   string
+		a, b int64
+	}
+
+	type Pair struct {
+		input    Args
+		expected string
+	}
+
+	pairs := []Pair{{Args{"+", 2, 3}, "5"}, {Args{"-", 2, 3}, "-1"},
+		{Args{"*", -4, 3}, "-12"}, {Args{"x", 4, 3}, "12"},
+		{Args{"mod", 7, 3}, "1"}, {Args{"mod", -7, 3}, "2"},
+		{Args{"mod", 7, -3}, "-2"}, {Args{"mod", 7, 0}, UNDEFINED},
+		{Args{"mod", math.MinInt64, -1}, "0"},
+		{Args{"pow", 2, 10}, "1024"}, {Args{"pow", -2, 3}, "-8"},
+		{Args{"pow", 0, 0}, "1"}, {Args{"pow", 2, -1}, UNDEFINED},
+		{Args{"pow", -1, -3}, "-1"}, {Args{"pow", -1, math.MaxInt64}, "-1"},
+		{Args{"pow", 3, 39}, "4052555153018976267"},
+		{Args{"pow", 3, 40}, UNDEFINED},
+		{Args{"gcd", 12, -18}, "6"}, {Args{"gcd", 0, 0}, "0"},
+		{Args{"gcd", math.MinInt64, 0}, UNDEFINED},
+		{Args{"xor", 5, 3}, "6"},
+		// Overflows
+		{Args{"+", math.MaxInt64, 1}, UNDEFINED},
+		{Args{"+", math.MinInt64, -1}, UNDEFINED},
+		{Args{"-", math.MinInt64, 1}, UNDEFINED},
+		{Args{"-", 0, math.MinInt64}, UNDEFINED},
+		{Args{"-", -1, math.MinInt64}, "9223372036854775807"},
+		{Args{"*", math.MinInt64, 1}, "-9223372036854775808"},
+		{Args{"*", math.MinInt64, -1}, UNDEFINED},
+		{Args{"*", 1 << 32, 1 << 31}, UNDEFINED},
+	}
+	for _, p := range pairs {
+		result := UNDEFINED
+		if v, err := OPS[p.input.op].Fn(p.input.a, p.input.b); err == nil {
+			result = (tableSpec{base: 10}).format(v)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	type Pair struct {
+		input    string
+		expected tableSpec
+	}
+
+	pairs := []Pair{
+		{"* 12", tableSpec{OPS["*"], [2]int64{1, 12}, [2]int64{1, 12}, 10,
+			false}},
+		{"+ -3..3 0..9 hex header", tableSpec{OPS["+"], [2]int64{-3, 3},
+			[2]int64{0, 9}, 16, true}},
+		{"xor 0..7 base 3", tableSpec{OPS["xor"], [2]int64{0, 7},
+			[2]int64{0, 7}, 3, false}},
+	}
+	for _, p := range pairs {
+		result, err := parseSpec(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected.op.Symbol != result.op.Symbol ||
+			p.expected.rows != result.rows || p.expected.cols != result.cols ||
+			p.expected.base != result.base || p.expected.header != result.header {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for _, input := range []string{"", "*", "/ 1..3", "* 3..1", "* 1..x",
+		"* 1..3 base 1", "* 1..3 base 37", "* 1..3 base", "* 1..3 wide",
+		"* 1..2000", "* -9223372036854775808..9223372036854775807"} {
+		if _, err := parseSpec(input); err == nil {
+			t.Fatalf("Input: %#v\nExpected an error", input)
+		}
+	}
+}
+
+func TestTables(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"* 1..4 header", strings.Join([]string{
+			"* | 1 2  3  4",
+			"--+----------",
+			"1 | 1 2  3  4",
+			"2 | 2 4  6  8",
+			"3 | 3 6  9 12",
+			"4 | 4 8 12 16",
+		}, "\n")},
+		{"mod -2..2 -1..2", strings.Join([]string{
+			"0 - 0 0",
+			"0 - 0 1",
+			"0 - 0 0",
+			"0 - 0 1",
+			"0 - 0 0",
+		}, "\n")},
+		{"xor 0..3 bin", strings.Join([]string{
+			" 0  1 10 11",
+			" 1  0 11 10",
+			"10 11  0  1",
+			"11 10  1  0",
+		}, "\n")},
+		// Past 3 digits, the columns still line up
+		{"* 31..32", strings.Join([]string{
+			"961  992",
+			"992 1024",
+		}, "\n")},
+		{"+ 9223372036854775806..9223372036854775807 0..1", strings.Join(
+			[]string{
+				"9223372036854775806 9223372036854775807",
+				"9223372036854775807                   -",
+			}, "\n")},
+		// The original problem, and more than one table at a time
+		{"3\npow 2 0..3 oct header", strings.Join([]string{
+			"1   2   3",
+			"2   4   6",
+			"3   6   9",
+			"",
+			"pow | 0 1 2  3",
+			"----+---------",
+			"  1 | 1 1 1  1",
+			"  2 | 1 2 4 10",
+		}, "\n")},
+	}
+	for _, p := range pairs {
+		result, err := MultiplicationTables([]byte(p.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestTableFormats(t *testing.T) {
+	type Pair struct {
+		format, input, expected string
+	}
+
+	pairs := []Pair{
+		{"csv", "* 2 header", "*,1,2\n1,1,2\n2,2,4"},
+		// The original problem isn't %4d columns in other formats
+		{"tsv", "2", "1\t2\n2\t4"},
+		{"markdown", "- 0..1 header", strings.Join([]string{
+			"| - | 0 | 1 |",
+			"| --: | --: | --: |",
+			"| **0** | 0 | -1 |",
+			"| **1** | 1 | 0 |",
+		}, "\n")},
+		{"latex", "mod 0..1 header", strings.Join([]string{
+			`\begin{tabular}{r|rr}`,
+			`mod & 0 & 1 \\`,
+			`\hline`,
+			`0 & - & 0 \\`,
+			`1 & - & 0 \\`,
+			`\end{tabular}`,
+		}, "\n")},
+	}
+	defer func() { renderer = table.Text{} }()
+	for _, p := range pairs {
+		var err error
+		if renderer, err = table.Lookup(p.format); err != nil {
+			t.Fatal(err)
+		}
+		got, err := MultiplicationTables([]byte(p.input))
+		if err != nil {
+			t.Fatalf("Input: %#v\nError: %v\n", p.input, err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.format+" "+p.input, p.expected, got)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package palindrome
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// ZERO_WIDTH_JOINER joins the characters on either side of it into one,
+// e.g. 👩 and 💻 into 👩‍💻.
+const ZERO_WIDTH_JOINER = '\u200d'
+
+// isExtend reports whether r attaches to the rune before it: combining and
+// enclosing marks (like the keycap in 1️⃣), variation selectors, emoji skin
+// tones and emoji tag characters.
+func isExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= 0xfe00 && r <= 0xfe0f, r >= 0xe0100 && r <= 0xe01ef:
+		// Variation selectors
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff:
+		// Skin tone modifiers
+		return true
+	case r >= 0xe0020 && r <= 0xe007f:
+		// Tags, as in the flags of England, Scotland and Wales
+		return true
+	}
+	return false
+}
+
+// isRegionalIndicator reports whether r is one of the letters that make up
+// flags in pairs, like 🇳 🇿 for 🇳🇿.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// Graphemes splits s into what a reader would see as single characters.
+// This is an approximation of the Unicode text segmentation rules (UAX #29)
+// that covers the cases that matter most for reversing text:
+//   - Combining marks, variation selectors, skin tones and tags stay with
+//     the character before them.
+//   - Anything joined with a zero width joiner, like 👩‍💻, is one cluster.
+//   - Regional indicators pair up into flags.
+//   - "\r\n" is one cluster.
+//
+// Hangul syllables spelled out in jamo and a few rarer scripts' rules are
+// not handled, so they are split a rune at a time.
+func Graphemes(s string) []string {
+	rv := []string{}
+	for start := 0; start < len(s); {
+		r, size := utf8.DecodeRuneInString(s[start:])
+		end := start + size
+
+		if r == '\r' && end < len(s) && s[end] == '\n' {
+			rv = append(rv, s[start:end+1])
+			start = end + 1
+			continue
+		}
+		if isRegionalIndicator(r) && end < len(s) {
+			next, size := utf8.DecodeRuneInString(s[end:])
+			if isRegionalIndicator(next) {
+				end += size
+			}
+		}
+
+		for end < len(s) {
+			next, size := utf8.DecodeRuneInString(s[end:])
+			switch {
+			case isExtend(next):
+				end += size
+				continue
+			case next == ZERO_WIDTH_JOINER:
+				end += size
+				// The joiner takes whatever comes after it too
+				if end < len(s) {
+					_, size = utf8.DecodeRuneInString(s[end:])
+					end += size
+				}
+				continue
+			}
+			break
+		}
+
+		rv = append(rv, s[start:end])
+		start = end
+	}
+	return rv
+}
@@ -0,0 +1,39 @@
+package palindrome
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	type Pair struct {
+		input    string
+		expected []string
+	}
+
+	pairs := []Pair{
+		{"abc", []string{"a", "b", "c"}},
+		{"", []string{}},
+		{"e\u0301a", []string{"e\u0301", "a"}},
+		{"a\r\nb", []string{"a", "\r\n", "b"}},
+		// Keycap one
+		{"1\ufe0f\u20e3!", []string{"1\ufe0f\u20e3", "!"}},
+		// Woman technologist with a skin tone
+		{"\U0001F469\U0001F3FD\u200d\U0001F4BBx",
+			[]string{"\U0001F469\U0001F3FD\u200d\U0001F4BB", "x"}},
+		// Three regional indicators make a flag and a leftover
+		{"\U0001F1F3\U0001F1FF\U0001F1EF",
+			[]string{"\U0001F1F3\U0001F1FF", "\U0001F1EF"}},
+		// Flag of Scotland, made with tags
+		{"\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074" +
+			"\U000E007F", []string{"\U0001F3F4\U000E0067\U000E0062" +
+			"\U000E0073\U000E0063\U000E0074\U000E007F"}},
+	}
+	for _, p := range pairs {
+		result := Graphemes(p.input)
+		if fmt.Sprintf("%q", p.expected) != fmt.Sprintf("%q", result) {
+			t.Fatalf("Input: %#v\nExpected: %q\n     Got: %q\n",
+				p.input, p.expected, result)
+		}
+	}
+}
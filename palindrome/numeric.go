@@ -0,0 +1,50 @@
+package palindrome
+
+// MIN_BASE and MAX_BASE are the bases numbers can be written in, the same as
+// strconv allows.
+const (
+	MIN_BASE = 2
+	MAX_BASE = 36
+)
+
+// Digits returns the digits of n in base b, least significant first.  b
+// must be from MIN_BASE to MAX_BASE.
+func Digits(n uint64, b int) []int {
+	if b < MIN_BASE || b > MAX_BASE {
+		panic("palindrome: illegal base")
+	}
+	rv := []int{int(n % uint64(b))}
+	for n /= uint64(b); n > 0; n /= uint64(b) {
+		rv = append(rv, int(n%uint64(b)))
+	}
+	return rv
+}
+
+// IsPalindromeBase reports whether n written in base b is a palindrome.  b
+// must be from MIN_BASE to MAX_BASE.
+func IsPalindromeBase(n uint64, b int) bool {
+	return isPalindrome(Digits(n, b))
+}
+
+// IsPalindromeBases reports whether n is a palindrome in every one of the
+// bases, e.g. 585 is 585 in base 10 and 1001001001 in base 2.
+func IsPalindromeBases(n uint64, bases ...int) bool {
+	for _, b := range bases {
+		if !IsPalindromeBase(n, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// PalindromicBases returns the bases from MIN_BASE to MAX_BASE that n is a
+// palindrome in.
+func PalindromicBases(n uint64) []int {
+	rv := []int{}
+	for b := MIN_BASE; b <= MAX_BASE; b++ {
+		if IsPalindromeBase(n, b) {
+			rv = append(rv, b)
+		}
+	}
+	return rv
+}
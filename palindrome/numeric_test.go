@@ -0,0 +1,62 @@
+package palindrome
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsPalindromeBase(t *testing.T) {
+	type Args struct {
+		n uint64
+		b int
+	}
+
+	type Pair struct {
+		input    Args
+		expected bool
+	}
+
+	pairs := []Pair{
+		{Args{0, 10}, true},
+		{Args{929, 10}, true},
+		{Args{930, 10}, false},
+		{Args{5, 2}, true},
+		{Args{6, 2}, false},
+		// zz in base 36
+		{Args{35*36 + 35, 36}, true},
+		{Args{1<<64 - 1, 2}, true},
+		{Args{1<<64 - 1, 10}, false},
+	}
+	for _, p := range pairs {
+		result := IsPalindromeBase(p.input.n, p.input.b)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestIsPalindromeBases(t *testing.T) {
+	if !IsPalindromeBases(585, 2, 10) {
+		t.Fatal("Expected 585 to be a palindrome in bases 2 and 10")
+	}
+	if IsPalindromeBases(586, 2, 10) {
+		t.Fatal("Expected 586 not to be a palindrome in bases 2 and 10")
+	}
+
+	// 10 is 1010 in base 2, 101 in base 3, 22 in base 4, 11 in base 9,
+	// and a single digit from base 11 on
+	result := fmt.Sprint(PalindromicBases(10)[:4])
+	if result != "[3 4 9 11]" {
+		t.Fatalf("Expected %v, got %v", "[3 4 9 11]", result)
+	}
+}
+
+func TestDigitsBadBase(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic for base 37")
+		}
+	}()
+	Digits(1, 37)
+}
@@ -0,0 +1,93 @@
+// Package palindrome tests for and finds palindromes, in numbers written in
+// any base and in Unicode text.
+//
+// Text is compared a grapheme cluster at a time rather than a byte or rune
+// at a time, so "é" written as e plus a combining accent, flags and emoji
+// sequences all stay in one piece when reversed (see Graphemes).
+package palindrome
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Option changes what counts when comparing text.  Options can be combined
+// with |.
+type Option int
+
+const (
+	// Upper and lower case are the same
+	IGNORE_CASE Option = 1 << iota
+	// Punctuation is skipped
+	IGNORE_PUNCT
+	// Whitespace is skipped
+	IGNORE_SPACE
+
+	// All of the above, for phrases like "A man, a plan, a canal: Panama"
+	IGNORE_ALL = IGNORE_CASE | IGNORE_PUNCT | IGNORE_SPACE
+)
+
+// cluster is a grapheme cluster in some text, along with where it was.
+type cluster struct {
+	str        string
+	start, end int
+}
+
+// clusters splits s into grapheme clusters, leaving out and normalizing
+// whatever opts say to.
+func clusters(s string, opts Option) []cluster {
+	rv := []cluster{}
+	start := 0
+	for _, g := range Graphemes(s) {
+		c := cluster{g, start, start + len(g)}
+		start = c.end
+
+		first := []rune(g)[0]
+		if opts&IGNORE_SPACE != 0 && unicode.IsSpace(first) ||
+			opts&IGNORE_PUNCT != 0 && unicode.IsPunct(first) {
+			continue
+		}
+		if opts&IGNORE_CASE != 0 {
+			c.str = strings.ToLower(c.str)
+		}
+		rv = append(rv, c)
+	}
+	return rv
+}
+
+// strs returns just the text of each cluster.
+func strs(cs []cluster) []string {
+	rv := make([]string, len(cs))
+	for i, c := range cs {
+		rv[i] = c.str
+	}
+	return rv
+}
+
+// isPalindrome reports whether s is the same forwards and backwards.
+func isPalindrome[T comparable](s []T) bool {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		if s[i] != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPalindrome reports whether s reads the same forwards and backwards, a
+// grapheme cluster at a time.
+func IsPalindrome(s string, opts Option) bool {
+	return isPalindrome(strs(clusters(s, opts)))
+}
+
+// Reverse reverses s a grapheme cluster at a time, so combining marks stay
+// on the character they were on.
+func Reverse(s string) string {
+	gs := Graphemes(s)
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := len(gs) - 1; i >= 0; i-- {
+		sb.WriteString(gs[i])
+	}
+	return sb.String()
+}
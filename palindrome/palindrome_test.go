@@ -0,0 +1,67 @@
+package palindrome
+
+import (
+	"testing"
+)
+
+func TestIsPalindrome(t *testing.T) {
+	type Args struct {
+		s    string
+		opts Option
+	}
+
+	type Pair struct {
+		input    Args
+		expected bool
+	}
+
+	pairs := []Pair{
+		{Args{"mom", 0}, true},
+		{Args{"hello", 0}, false},
+		{Args{"", 0}, true},
+		{Args{"Mom", 0}, false},
+		{Args{"Mom", IGNORE_CASE}, true},
+		{Args{"A man, a plan, a canal: Panama", IGNORE_CASE}, false},
+		{Args{"A man, a plan, a canal: Panama", IGNORE_ALL}, true},
+		{Args{"no lemon, no melon", IGNORE_PUNCT | IGNORE_SPACE}, true},
+		{Args{"été", 0}, true},
+		// The same with e and a combining acute accent, which would be
+		// out of place reversed a rune at a time
+		{Args{"e\u0301te\u0301", 0}, true},
+		{Args{"e\u0301the\u0301", 0}, false},
+		// Written both ways, which isn't normalized
+		{Args{"éte\u0301", 0}, false},
+		{Args{"\U0001F1F3\U0001F1FF!\U0001F1F3\U0001F1FF", 0}, true},
+		{Args{"日本日", 0}, true},
+	}
+	for _, p := range pairs {
+		result := IsPalindrome(p.input.s, p.input.opts)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"hello", "olleh"},
+		{"", ""},
+		{"日本語", "語本日"},
+		{"cafe\u0301", "e\u0301fac"},
+		// Flags of New Zealand and Japan
+		{"\U0001F1F3\U0001F1FF\U0001F1EF\U0001F1F5",
+			"\U0001F1EF\U0001F1F5\U0001F1F3\U0001F1FF"},
+	}
+	for _, p := range pairs {
+		result := Reverse(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package palindrome
+
+import (
+	"iter"
+	"slices"
+)
+
+// Longest finds the longest palindrome in s with Manacher's algorithm, in
+// O(n).  It returns the start and end, so the palindrome is s[start:end].
+// Of several as long, it picks the first.
+func Longest[T comparable](s []T) (start, end int) {
+	if len(s) == 0 {
+		return 0, 0
+	}
+
+	// Work on s with a gap between every element (and at the ends), so
+	// even and odd length palindromes are both centered on something.
+	// Position i is s[i/2] when i is odd and a gap when it is even.
+	n := 2*len(s) + 1
+	at := func(i int) (T, bool) {
+		var zero T
+		if i%2 == 0 {
+			return zero, false
+		}
+		return s[i/2], true
+	}
+	same := func(i, j int) bool {
+		a, aOK := at(i)
+		b, bOK := at(j)
+		return aOK == bOK && a == b
+	}
+
+	// radius[i] is how far the palindrome centered on i reaches either
+	// way, which is also its length in s
+	radius := make([]int, n)
+	center, right := 0, 0
+	best := 0
+	for i := 0; i < n; i++ {
+		// A palindrome reflected inside a bigger one is at least as
+		// long, as far as the bigger one goes
+		if i < right {
+			radius[i] = min(radius[2*center-i], right-i)
+		}
+		for i-radius[i]-1 >= 0 && i+radius[i]+1 < n &&
+			same(i-radius[i]-1, i+radius[i]+1) {
+			radius[i]++
+		}
+		if i+radius[i] > right {
+			center, right = i, i+radius[i]
+		}
+		if radius[i] > radius[best] {
+			best = i
+		}
+	}
+	start = (best - radius[best]) / 2
+	return start, start + radius[best]
+}
+
+// LongestPalindrome returns the longest palindrome in s, a grapheme cluster
+// at a time.  With opts, what is skipped is still included in the result
+// if it falls inside it, so the result is always a piece of s.
+func LongestPalindrome(s string, opts Option) string {
+	cs := clusters(s, opts)
+	start, end := Longest(strs(cs))
+	if start == end {
+		return ""
+	}
+	return s[cs[start].start:cs[end-1].end]
+}
+
+// palindromeTable returns isPal[i][j], whether s[i:j+1] is a palindrome,
+// for every i <= j.
+func palindromeTable[T comparable](s []T) [][]bool {
+	isPal := make([][]bool, len(s))
+	for i := len(s) - 1; i >= 0; i-- {
+		isPal[i] = make([]bool, len(s))
+		for j := i; j < len(s); j++ {
+			isPal[i][j] = s[i] == s[j] && (j-i < 2 || isPal[i+1][j-1])
+		}
+	}
+	return isPal
+}
+
+// partitions yields every way to cut s[start:] into palindromes, each
+// appended to prefix.
+func partitions[T comparable](s []T, isPal [][]bool, start int,
+	prefix [][]T, yield func([][]T) bool) bool {
+	if start == len(s) {
+		return yield(slices.Clone(prefix))
+	}
+	for end := start; end < len(s); end++ {
+		if isPal[start][end] && !partitions(s, isPal, end+1,
+			append(prefix, s[start:end+1]), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Partitions iterates over every way to cut s into pieces that are all
+// palindromes.  There can be up to 2**(len(s)-1) of them.
+func Partitions[T comparable](s []T) iter.Seq[[][]T] {
+	return func(yield func([][]T) bool) {
+		if len(s) == 0 {
+			yield([][]T{})
+			return
+		}
+		partitions(s, palindromeTable(s), 0, nil, yield)
+	}
+}
+
+// PalindromePartitions is Partitions for text, cut between grapheme
+// clusters.
+func PalindromePartitions(s string) iter.Seq[[]string] {
+	return func(yield func([]string) bool) {
+		for parts := range Partitions(Graphemes(s)) {
+			strs := make([]string, len(parts))
+			for i, part := range parts {
+				for _, g := range part {
+					strs[i] += g
+				}
+			}
+			if !yield(strs) {
+				return
+			}
+		}
+	}
+}
+
+// MinCuts returns the fewest cuts it takes to split s into palindromes, in
+// O(n**2).  It is 0 if s is already a palindrome.
+func MinCuts[T comparable](s []T) int {
+	if len(s) == 0 {
+		return 0
+	}
+	isPal := palindromeTable(s)
+	// cuts[j] is the fewest cuts for s[:j+1]
+	cuts := make([]int, len(s))
+	for j := range s {
+		cuts[j] = j
+		for i := 0; i <= j; i++ {
+			if !isPal[i][j] {
+				continue
+			}
+			if i == 0 {
+				cuts[j] = 0
+				break
+			}
+			cuts[j] = min(cuts[j], cuts[i-1]+1)
+		}
+	}
+	return cuts[len(s)-1]
+}
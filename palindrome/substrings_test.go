@@ -0,0 +1,104 @@
+package palindrome
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestLongestPalindrome(t *testing.T) {
+	type Args struct {
+		s    string
+		opts Option
+	}
+
+	type Pair struct {
+		input    Args
+		expected string
+	}
+
+	pairs := []Pair{
+		{Args{"", 0}, ""},
+		{Args{"a", 0}, "a"},
+		{Args{"babad", 0}, "bab"},
+		{Args{"cbbd", 0}, "bb"},
+		{Args{"forgeeksskeegfor", 0}, "geeksskeeg"},
+		{Args{"x日本日y", 0}, "日本日"},
+		{Args{"Was it a car or a cat I saw?", 0}, " a "},
+		{Args{"Was it a car or a cat I saw?", IGNORE_ALL},
+			"Was it a car or a cat I saw"},
+	}
+	for _, p := range pairs {
+		result := LongestPalindrome(p.input.s, p.input.opts)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+// slowLongest checks every substring, longest first.
+func slowLongest(s []byte) int {
+	for length := len(s); length > 0; length-- {
+		for start := 0; start+length <= len(s); start++ {
+			if isPalindrome(s[start : start+length]) {
+				return length
+			}
+		}
+	}
+	return 0
+}
+
+func TestLongestRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		s := make([]byte, rand.Intn(20))
+		for j := range s {
+			s[j] = "ab"[rand.Intn(2)]
+		}
+		start, end := Longest(s)
+		if !isPalindrome(s[start:end]) || end-start != slowLongest(s) {
+			t.Fatalf("Input: %q\nExpected length: %v\n     Got: %q\n",
+				s, slowLongest(s), s[start:end])
+		}
+	}
+}
+
+func TestPalindromePartitions(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"", "[[]]"},
+		{"aab", "[[a a b] [aa b]]"},
+		{"nitin", "[[n i t i n] [n iti n] [nitin]]"},
+		{"e\u0301e\u0301", "[[e\u0301 e\u0301] [e\u0301e\u0301]]"},
+	}
+	for _, p := range pairs {
+		result := [][]string{}
+		for parts := range PalindromePartitions(p.input) {
+			result = append(result, parts)
+		}
+		if p.expected != fmt.Sprint(result) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, fmt.Sprint(result))
+		}
+	}
+}
+
+func TestMinCuts(t *testing.T) {
+	type Pair struct {
+		input    string
+		expected int
+	}
+
+	pairs := []Pair{{"", 0}, {"a", 0}, {"aab", 1}, {"abc", 2},
+		{"ababbbabbababa", 3}, {"racecar", 0}}
+	for _, p := range pairs {
+		result := MinCuts([]byte(p.input))
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
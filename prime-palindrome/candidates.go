@@ -0,0 +1,129 @@
+package main
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/carbonizer/codeeval-go/primes"
+)
+
+// numDigits returns how many digits n has in base b.
+func numDigits(n, b uint64) int {
+	count := 1
+	for ; n >= b; n /= b {
+		count++
+	}
+	return count
+}
+
+// pow returns b**e.  The caller must know it fits in a uint64.
+func pow(b uint64, e int) uint64 {
+	rv := uint64(1)
+	for ; e > 0; e-- {
+		rv *= b
+	}
+	return rv
+}
+
+// makePalindrome mirrors half in base b to make a palindrome, e.g. 123
+// makes 12321 if odd is true and 123321 if it isn't.  It returns false if
+// the palindrome doesn't fit in a uint64.
+func makePalindrome(half, b uint64, odd bool) (uint64, bool) {
+	pal, rest := half, half
+	if odd {
+		rest /= b
+	}
+	for ; rest > 0; rest /= b {
+		hi, lo := bits.Mul64(pal, b)
+		sum, carry := bits.Add64(lo, rest%b, 0)
+		if hi != 0 || carry != 0 {
+			return 0, false
+		}
+		pal = sum
+	}
+	return pal, true
+}
+
+// primePalindromeCandidates iterates over the palindromes in base b from lo
+// to hi, including both, that could be prime.  They come in increasing
+// order, or decreasing if desc is true.  Instead of checking every number,
+// each palindrome is built from its first half, and whole blocks are
+// skipped:
+//   - Every even-length palindrome in base b is divisible by b+1, so "11"
+//     (b+1 itself) is the only one that can be prime.
+//   - A palindrome ends with its first digit, so past one digit, halves
+//     starting with a digit that shares a factor with b are skipped.  In
+//     base 10 that is 2, 4, 5, 6 and 8.
+func primePalindromeCandidates(lo, hi, b uint64, desc bool) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		if lo > hi {
+			return
+		}
+		loLen, hiLen := numDigits(lo, b), numDigits(hi, b)
+
+		// Lengths in the order the palindromes will come out
+		lengths := make([]int, 0, hiLen-loLen+1)
+		for length := loLen; length <= hiLen; length++ {
+			lengths = append(lengths, length)
+		}
+		if desc {
+			slices.Reverse(lengths)
+		}
+
+		for _, length := range lengths {
+			if length == 2 {
+				if lo <= b+1 && b+1 <= hi && !yield(b+1) {
+					return
+				}
+				continue
+			}
+			if length%2 == 0 {
+				continue
+			}
+			if !oddLengthCandidates(lo, hi, b, length, desc, yield) {
+				return
+			}
+		}
+	}
+}
+
+// oddLengthCandidates yields the candidates of one odd length for
+// primePalindromeCandidates.  It returns false if yield did.
+func oddLengthCandidates(lo, hi, b uint64, length int, desc bool,
+	yield func(uint64) bool) bool {
+	halfLen := (length + 1) / 2
+	lowest := pow(b, halfLen-1)
+	first, last := lowest, pow(b, halfLen)-1
+	// At the ends of the range, the halves start or stop at the first
+	// half of lo or hi
+	if length == numDigits(lo, b) {
+		first = max(first, lo/pow(b, length-halfLen))
+	}
+	if length == numDigits(hi, b) {
+		last = hi / pow(b, length-halfLen)
+	}
+
+	half, step := first, uint64(1)
+	if desc {
+		half, step = last, ^uint64(0)
+	}
+	for first <= half && half <= last {
+		lead := half / lowest
+		if length > 1 && primes.GCD(lead, b) != 1 {
+			// Straight to the next block in the right direction
+			if desc {
+				half = lead*lowest - 1
+			} else {
+				half = (lead + 1) * lowest
+			}
+			continue
+		}
+		pal, ok := makePalindrome(half, b, true)
+		if ok && lo <= pal && pal <= hi && !yield(pal) {
+			return false
+		}
+		half += step
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMakePalindrome(t *testing.T) {
+	type Args struct {
+		half, b uint64
+		odd     bool
+	}
+
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{{Args{123, 10, true}, 12321},
+		{Args{123, 10, false}, 123321}, {Args{7, 10, true}, 7},
+		{Args{10, 10, true}, 101}, {Args{0b110, 2, true}, 0b11011},
+		// Too big to fit
+		{Args{9999999999, 10, false}, 0}}
+	for _, p := range pairs {
+		result, ok := makePalindrome(p.input.half, p.input.b, p.input.odd)
+		if p.expected != result || ok != (result != 0) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestPrimePalindromeCandidates(t *testing.T) {
+	type Args struct {
+		lo, hi, b uint64
+		desc      bool
+	}
+
+	type Pair struct {
+		input    Args
+		expected string
+	}
+
+	pairs := []Pair{
+		{Args{0, 0, 10, true}, "[]"},
+		{Args{0, 11, 10, true}, "[11 9 8 7 6 5 4 3 2 1]"},
+		{Args{0, 10, 10, true}, "[9 8 7 6 5 4 3 2 1]"},
+		// Nothing starting with 2, 4, 5, 6 or 8, and no 4 digits
+		{Args{0, 9999, 10, true}, "[999 989 979 969 959 949 939 929 " +
+			"919 909 797 787 777 767 757 747 737 727 717 707 393 383 " +
+			"373 363 353 343 333 323 313 303 191 181 171 161 151 141 " +
+			"131 121 111 101 11 9 8 7 6 5 4 3 2 1]"},
+		{Args{8, 319, 10, true}, "[313 303 191 181 171 161 151 141 131 " +
+			"121 111 101 11 9 8]"},
+		{Args{8, 319, 10, false}, "[8 9 11 101 111 121 131 141 151 161 " +
+			"171 181 191 303 313]"},
+		{Args{120, 130, 10, false}, "[121]"},
+		{Args{30, 2, 10, false}, "[]"},
+		// 1, 11, 101, 111, 10001, ... in base 2
+		{Args{0, 31, 2, false}, "[1 3 5 7 17 21 27 31]"},
+		// Base 6 only leads with 1 or 5
+		{Args{36, 215, 6, false}, "[37 43 49 55 61 67 185 191 197 203 " +
+			"209 215]"},
+	}
+	for _, p := range pairs {
+		result := fmt.Sprint(slices.Collect(primePalindromeCandidates(
+			p.input.lo, p.input.hi, p.input.b, p.input.desc)))
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
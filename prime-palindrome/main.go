@@ -1,94 +1,188 @@
 package main
 
+// Common imports for main and handling input
 import (
 	"fmt"
 	"io/ioutil"
+	"log"
 	"os"
 )
 
+// Imports for the specific problem
 import (
-	"strconv"
 	"errors"
+	"iter"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/primes"
 )
 
-// reverse reverses a string of single-byte runes.
-func reverse(str string) string {
-	strLen := len(str)
-	r := make([]byte, strLen)
-	for i := 0; i < strLen; i++ {
-		r[i] = str[strLen-i-1]
-	}
-	return string(r)
-}
+//
+// Common logic for handling imports
+//
+
+type InputType int
+
+const (
+	// No Input
+	INPUT_NONE InputType = iota
+	// Input via stdin.  This is common on hackerrank.com.
+	INPUT_STDIN
+	// Input from the file at the path of the first command argument.  This
+	// is common on codeeval.com.
+	INPUT_FILEARG
+	// Fake input with a constant string.  This can also be used is a
+	// problem indicates using a const value, but you want to write you
+	// function more generically
+	INPUT_CONSTANT
+)
 
-// reverse returns a copy of a slice with the elements in the opposite order.
-// This does not work for strings with multi-byte runes
-//func reverse(a interface{}) interface{} {
-//	val := reflect.ValueOf(a)
-//	valLen := val.Len()
-//	r := make([]interface{}, valLen)
-//	for i := 0; i < valLen; i++ {
-//		r[i] = val.Index(valLen - i - 1).Interface()
-//	}
-//	return r
-//}
-
-// isPrime returns true if n is a prime number.
-func isPrime(n uint) bool {
-	switch n {
-	case 0, 1:
-		return false
+// inputToFuncToStdout wraps a custom function to simplify input and output.
+// Various websites provide programming challenges to practice using multiple
+// languages and techniques.  Some of the sites (such as codewars.com) test
+// submissions using language-specific unit tests.  Others, input data using
+// methods common to all languages, and test the output of the program.
+// Handling the output is usually as simple as printing to stdout.  However,
+// the input is often more complicated, and sometime the code to handle the
+// input is not provide.  Another issue is that if you develop the solution
+// offline, you may want to use a different method of input while debugging.
+//
+// This function handles the input and output so you can focus on writing the
+// custom code for the specific problem.  Pass in a function that matches the
+// signature, and indicate the type of input (in the case of INPUT_CONSTANT,
+// update FAKE_INPUT accordingly).  The input will be passed to the function as
+// a slice of bytes.  If the function runs successfully, the "%v" form of the
+// return value with be printed to stdout.
+func inputToFuncToStdout(fn func([]byte) (interface{}, error), it InputType) {
+	fp, input, err := (*os.File)(nil), []byte{}, error(nil)
+
+	switch it {
+	case INPUT_STDIN:
+		fp = os.Stdin
+
+	case INPUT_FILEARG:
+		fp, err = os.Open(os.Args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	case INPUT_CONSTANT:
+		input = []byte(FAKE_INPUT)
+
+	// No Input
 	default:
-		for i := uint(2); i < n; i++ {
-			// i is a factor if the remainder is 0
-			if n % i == 0 {
-				return false
-			}
+		//log.Println("No input")
+	}
+
+	if fp != nil {
+		// Read all of input from file pointer
+		input, err = ioutil.ReadAll(fp)
+		if err != nil {
+			fp.Close()
+			log.Fatal(err)
+		}
+		fp.Close()
+	}
+
+	// Call fn with input
+	defer func() {
+		if r := recover(); r != nil {
+			log.Fatalf("Panic while running fn\n"+
+				"Input: %#v\n"+
+				"Error: %v\n", string(input), r)
 		}
+	}()
+	rv, err := fn(input)
+	if err != nil {
+		fmt.Println(err)
+		return
 	}
-	return true
+
+	// Print result to stdout
+	fmt.Println(rv)
+}
+
+//
+// Custom code for this problem
+//
+
+// The original problem, with no input, is the greatest below 1000
+const FAKE_INPUT = "max 1000"
+
+// Just update the function name and the input type
+func main() {
+	inputToFuncToStdout(PrimePalindrome, INPUT_FILEARG)
 }
 
-// isPalindrome returns true if a string is the same forwards and backwards
-func isPalindrome(str string) bool {
-	rev := reverse(str)
-	return str == rev
+// greatestPrimePalindrome returns the greatest prime palindrome less than n.
+// Only palindromes are ever looked at (see primePalindromeCandidates), and
+// they are tested with Miller-Rabin, so it is fast all the way up to 2**64.
+func greatestPrimePalindrome(n uint64) (uint64, error) {
+	return greatestPrimePalindromeBase(n, 10)
 }
 
-// greatestPrimePalindrome returns the greatest prime palindrome less than n
-func greatestPrimePalindrome(n uint) (uint, error) {
-	for i := n - 1; i > 1; i-- {
-		str := strconv.Itoa(int(i))
-		if isPalindrome(str) && isPrime(i) {
-			return i, nil
+// greatestPrimePalindromeBase is greatestPrimePalindrome for palindromes in
+// base b.
+func greatestPrimePalindromeBase(n, b uint64) (uint64, error) {
+	if n > 0 {
+		for pal := range primePalindromes(0, n-1, b, true) {
+			return pal, nil
 		}
 	}
 	return 0, errors.New(fmt.Sprint("No prime palindrome < ", n))
 }
 
-func gpp1000([]byte) (interface{}, error) {
-	return greatestPrimePalindrome(1000)
+// primePalindromes iterates over the prime palindromes in base b from lo to
+// hi, including both, in increasing order or decreasing if desc is true.
+// With a cached sieve (see primes.Cached), candidates it covers are looked
+// up rather than tested.
+func primePalindromes(lo, hi, b uint64, desc bool) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		isPrime := primes.IsPrime
+		if s := primes.Cached(hi); s != nil {
+			isPrime = s.IsPrime
+		}
+		for pal := range primePalindromeCandidates(lo, hi, b, desc) {
+			if isPrime(pal) && !yield(pal) {
+				return
+			}
+		}
+	}
 }
 
-func stdinToFuncToStdout(fn func([]byte) (interface{}, error)) {
-	// Read in all of stdin
-	stdin, err := ioutil.ReadAll(os.Stdin)
-	if err != nil {
-		fmt.Println(err)
-		return
+// PrimePalindrome answers one query per line about prime palindromes (see
+// parseQuery), e.g. "max 1000", "range 100 200 count", "nth 20" or
+// "base 2 max 1000".  A line like "format base comma" changes how the
+// answers after it are written (see parseFormat).  Empty input answers the
+// original problem, "max 1000".
+func PrimePalindrome(input []byte) (interface{}, error) {
+	text := strings.TrimSpace(string(input))
+	if text == "" {
+		text = FAKE_INPUT
 	}
 
-	// Get call fn with stdin
-	rv, err := fn(stdin)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
+	f := outputFormat{sep: " "}
+	outLines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "format" {
+			var err error
+			if f, err = parseFormat(fields[1:]); err != nil {
+				return strings.Join(outLines, "\n"), err
+			}
+			continue
+		}
 
-	// Print result to stdout
-	fmt.Println(rv)
-}
+		q, err := parseQuery(line)
+		if err != nil {
+			return strings.Join(outLines, "\n"), err
+		}
+		answer, err := q.answer(f)
+		if err != nil {
+			return strings.Join(outLines, "\n"), err
+		}
+		outLines = append(outLines, answer)
+	}
 
-func main() {
-	stdinToFuncToStdout(gpp1000)
+	return strings.Join(outLines, "\n"), nil
 }
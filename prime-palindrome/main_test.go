@@ -2,73 +2,94 @@ package main
 
 import (
 	"testing"
-	"fmt"
-)
 
+	"github.com/carbonizer/codeeval-go/palindrome"
+	"github.com/carbonizer/codeeval-go/primes"
+)
 
-func TestReverse(t *testing.T) {
+func TestGreatestPrimePalindrome(t *testing.T) {
 	type Pair struct {
-		input, expected string
+		input, expected uint64
 	}
 
-	pairs := []Pair{{"hello", "ollehh"}}
+	pairs := []Pair{{1e5, 98689}, {10000, 929}, {1000, 929}, {100, 11},
+		{10, 7}, {12, 11}, {11, 7}, {3, 2}, {1e9, 999727999},
+		{1e12, 99999199999}, {1e18, 99999999299999999},
+		{1<<64 - 1, 9999999992999999999}}
 	for _, p := range pairs {
-		result := reverse(p.input)
-		if p.expected == result {
-			fmt.Println("Input:", p.input)
-			t.Fatalf("Expected %v, got %v", p.expected, result)
+		result, err := greatestPrimePalindrome(p.input)
+		if err != nil {
+			t.Fatal(err)
 		}
-	}
-}
-
-
-func TestIsPrime(t *testing.T) {
-	type Pair struct {
-		input uint
-		expected bool
-	}
-
-	pairs := []Pair{{0, false}, {1, false}, {2, true}, {3, true},
-			{4, false}, {7, true}}
-	for _, p := range pairs {
-		result := isPrime(p.input)
 		if p.expected != result {
-			fmt.Println("Input:", p.input)
 			t.Fatalf("Expected %v, got %v", p.expected, result)
 		}
 	}
 }
 
-func TestIsPalindrome(t *testing.T) {
-	type Pair struct {
-		input string
-		expected bool
+func TestGreatestPrimePalindromeSmall(t *testing.T) {
+	// Checking every number the slow way
+	slow := uint64(0)
+	for n := uint64(3); n < 2e5; n++ {
+		if palindrome.IsPalindromeBase(n-1, 10) && primes.IsPrime(n-1) {
+			slow = n - 1
+		}
+		result, err := greatestPrimePalindrome(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slow != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				n, slow, result)
+		}
 	}
 
-	pairs := []Pair{{"mom", true}, {"hello", false}}
-	for _, p := range pairs {
-		result := isPalindrome(p.input)
-		if p.expected != result {
-			fmt.Println("Input:", p.input)
-			t.Fatalf("Expected %v, got %v", p.expected, result)
-		}
+	if _, err := greatestPrimePalindrome(2); err == nil {
+		t.Fatal("Expected an error with no prime palindrome below 2")
 	}
 }
 
-func TestGreatestPrimePalindrome(t *testing.T) {
+func TestPrimePalindrome(t *testing.T) {
 	type Pair struct {
-		input, expected uint
+		input, expected string
 	}
 
-	pairs := []Pair{{1e5, 98689}, {10000, 929}, {1000, 929}, {100, 11},
-			{10, 7}}
+	pairs := []Pair{
+		{"", "929"},
+		{`max 1000
+range 100 200
+range 100 200 count
+nth 20
+base 2 max 1000
+format base comma
+base 2 max 1000
+base 2 range 1 20
+format
+base 2 range 1 20`,
+			`929
+101 131 151 181 191
+5
+929
+443
+110111011
+11,101,111,10001
+3 5 7 17`},
+		{"max 1000000000000000000", "99999999299999999"},
+	}
 	for _, p := range pairs {
-		result, err := greatestPrimePalindrome(p.input)
+		result, err := PrimePalindrome([]byte(p.input))
 		if err != nil {
 			t.Fatal(err)
 		}
 		if p.expected != result {
-			t.Fatalf("Expected %v, got %v", p.expected, result)
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for _, input := range []string{"max 2", "nth 0", "format hex"} {
+		if _, err := PrimePalindrome([]byte(input)); err == nil {
+			t.Fatalf("Expected an error for %#v", input)
 		}
 	}
 }
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// QueryType is what a line of input asks for.
+type QueryType int
+
+const (
+	// The greatest prime palindrome below N
+	QUERY_MAX QueryType = iota
+	// Every prime palindrome from A to B, or how many there are
+	QUERY_RANGE
+	// The Kth smallest prime palindrome, counting from 1
+	QUERY_NTH
+)
+
+// query is one parsed line of input.
+type query struct {
+	qt QueryType
+	// Palindromes are read in this base, but numbers in the query are
+	// always decimal
+	base uint64
+	args []uint64
+	// For QUERY_RANGE, answer with how many instead of listing them
+	count bool
+}
+
+// parseQuery parses a line of "[base B] max N", "[base B] range A B
+// [list|count]" or "[base B] nth K".  The base is 10 if it isn't given, and
+// it can be from 2 to 36.
+func parseQuery(line string) (query, error) {
+	fields := strings.Fields(line)
+	q := query{base: 10}
+
+	if len(fields) >= 2 && fields[0] == "base" {
+		base, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil || base < 2 || base > 36 {
+			return query{}, fmt.Errorf("Cannot parse base %#v", fields[1])
+		}
+		q.base = base
+		fields = fields[2:]
+	}
+	if len(fields) == 0 {
+		return query{}, fmt.Errorf("Cannot parse %#v", line)
+	}
+
+	numArgs := 1
+	switch fields[0] {
+	case "max":
+		q.qt = QUERY_MAX
+	case "range":
+		q.qt, numArgs = QUERY_RANGE, 2
+		// The last field can be list or count
+		switch fields[len(fields)-1] {
+		case "count":
+			q.count = true
+			fallthrough
+		case "list":
+			fields = fields[:len(fields)-1]
+		}
+	case "nth":
+		q.qt = QUERY_NTH
+	default:
+		return query{}, fmt.Errorf("Unknown query %#v", fields[0])
+	}
+	if len(fields) != numArgs+1 {
+		return query{}, fmt.Errorf("Cannot parse %#v", line)
+	}
+
+	for _, field := range fields[1:] {
+		arg, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return query{}, fmt.Errorf("Cannot parse %#v", field)
+		}
+		q.args = append(q.args, arg)
+	}
+	return q, nil
+}
+
+// outputFormat is how answers are written.
+type outputFormat struct {
+	// Write palindromes in the query's base instead of decimal
+	inBase bool
+	// Goes between the palindromes a range lists
+	sep string
+}
+
+// parseFormat parses the rest of a "format" line.  Each field changes one
+// thing, and anything not mentioned goes back to the default:
+//   - dec (the default) or base: which base palindromes are written in
+//   - space (the default), comma or line: what goes between the palindromes
+//     a range lists
+func parseFormat(fields []string) (outputFormat, error) {
+	f := outputFormat{sep: " "}
+	for _, field := range fields {
+		switch field {
+		case "dec":
+			f.inBase = false
+		case "base":
+			f.inBase = true
+		case "space":
+			f.sep = " "
+		case "comma":
+			f.sep = ","
+		case "line":
+			f.sep = "\n"
+		default:
+			return outputFormat{}, fmt.Errorf("Unknown format %#v", field)
+		}
+	}
+	return f, nil
+}
+
+// formatPalindrome writes a palindrome from a query in base b.
+func (f outputFormat) formatPalindrome(pal, b uint64) string {
+	if f.inBase {
+		return strconv.FormatUint(pal, int(b))
+	}
+	return strconv.FormatUint(pal, 10)
+}
+
+// answer answers the query, written in format f.
+func (q query) answer(f outputFormat) (string, error) {
+	switch q.qt {
+	case QUERY_MAX:
+		pal, err := greatestPrimePalindromeBase(q.args[0], q.base)
+		if err != nil {
+			return "", err
+		}
+		return f.formatPalindrome(pal, q.base), nil
+
+	case QUERY_RANGE:
+		strs, count := []string{}, 0
+		for pal := range primePalindromes(q.args[0], q.args[1], q.base,
+			false) {
+			count++
+			if !q.count {
+				strs = append(strs, f.formatPalindrome(pal, q.base))
+			}
+		}
+		if q.count {
+			return strconv.Itoa(count), nil
+		}
+		return strings.Join(strs, f.sep), nil
+
+	case QUERY_NTH:
+		k, seen := q.args[0], uint64(0)
+		if k == 0 {
+			return "", fmt.Errorf("Prime palindromes count from 1")
+		}
+		for pal := range primePalindromes(0, math.MaxUint64, q.base,
+			false) {
+			if seen++; seen == k {
+				return f.formatPalindrome(pal, q.base), nil
+			}
+		}
+		return "", fmt.Errorf("No prime palindrome number %d", k)
+	}
+	return "", fmt.Errorf("Unknown query type %v", q.qt)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{"max 1000", "{0 10 [1000] false}"},
+		{"range 100 200", "{1 10 [100 200] false}"},
+		{"range 100 200 list", "{1 10 [100 200] false}"},
+		{"range 100 200 count", "{1 10 [100 200] true}"},
+		{"base 2 nth 7", "{2 2 [7] false}"},
+	}
+	for _, p := range pairs {
+		q, err := parseQuery(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result := fmt.Sprint(q); p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for _, input := range []string{"", "base 2", "base 37 max 10",
+		"max", "max 1 2", "range 1", "range 1 2 all", "nth -1",
+		"min 10"} {
+		if _, err := parseQuery(input); err == nil {
+			t.Fatalf("Expected an error for %#v", input)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := parseFormat(strings.Fields("base comma"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.inBase || f.sep != "," {
+		t.Fatalf("Expected base and comma, got %#v", f)
+	}
+	if _, err := parseFormat([]string{"hex"}); err == nil {
+		t.Fatal("Expected an error for hex")
+	}
+}
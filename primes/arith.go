@@ -0,0 +1,91 @@
+package primes
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrNoInverse is returned when a number has no inverse mod m, because they
+// share a factor.
+var ErrNoInverse = errors.New("primes: no modular inverse")
+
+// mulMod returns a * b % m without overflowing.  a and b must be less than
+// m.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, m)
+	return rem
+}
+
+// addMod returns (a + b) % m without overflowing.  a and b must be less than
+// m.
+func addMod(a, b, m uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 || sum >= m {
+		sum -= m
+	}
+	return sum
+}
+
+// MulMod returns a * b % m, without the product overflowing.
+func MulMod(a, b, m uint64) uint64 {
+	return mulMod(a%m, b%m, m)
+}
+
+// PowMod returns b**e % m by repeated squaring.
+func PowMod(b, e, m uint64) uint64 {
+	rv := 1 % m
+	b %= m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			rv = mulMod(rv, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return rv
+}
+
+// InvMod returns the x in [0, m) with a * x % m == 1, or ErrNoInverse if
+// a and m share a factor.
+func InvMod(a, m uint64) (uint64, error) {
+	if m == 0 {
+		return 0, ErrNoInverse
+	}
+	// The extended Euclidean algorithm, keeping the coefficients of a
+	// mod m so they never go negative or overflow
+	r0, r1 := m, a%m
+	t0, t1 := uint64(0), 1%m
+	for r1 != 0 {
+		q := r0 / r1
+		r0, r1 = r1, r0-q*r1
+		t0, t1 = t1, addMod(t0, m-mulMod(q%m, t1, m), m)
+	}
+	if r0 != 1 {
+		if m == 1 {
+			return 0, nil
+		}
+		return 0, ErrNoInverse
+	}
+	return t0, nil
+}
+
+// GCD returns the greatest common divisor of a and b.  GCD(0, 0) is 0.
+func GCD(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// LCM returns the least common multiple of a and b, or ErrRange if it
+// doesn't fit in a uint64.  LCM(0, n) is 0.
+func LCM(a, b uint64) (uint64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	hi, lo := bits.Mul64(a/GCD(a, b), b)
+	if hi != 0 {
+		return 0, ErrRange
+	}
+	return lo, nil
+}
@@ -0,0 +1,108 @@
+package primes
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPowMod(t *testing.T) {
+	type Args struct {
+		b, e, m uint64
+	}
+
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{{Args{2, 10, 1000}, 24}, {Args{5, 0, 7}, 1},
+		{Args{5, 0, 1}, 0}, {Args{0, 0, 7}, 1},
+		// Fermat's little theorem, with a base bigger than the modulus
+		{Args{1<<64 - 1, 18446744073709551556, 18446744073709551557}, 1},
+		{Args{1<<64 - 1, 2, 1<<64 - 1}, 0}}
+	for _, p := range pairs {
+		result := PowMod(p.input.b, p.input.e, p.input.m)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	if result := MulMod(1<<64-1, 1<<64-1, 1<<63); result != 1 {
+		t.Fatalf("Expected 1, got %v", result)
+	}
+}
+
+func TestInvMod(t *testing.T) {
+	type Args struct {
+		a, m uint64
+	}
+
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{{Args{3, 7}, 5}, {Args{10, 17}, 12}, {Args{1, 1}, 0},
+		{Args{2, 1<<64 - 1}, 1 << 63}}
+	for _, p := range pairs {
+		result, err := InvMod(p.input.a, p.input.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for _, args := range []Args{{6, 9}, {0, 7}, {5, 0}} {
+		if _, err := InvMod(args.a, args.m); err != ErrNoInverse {
+			t.Fatalf("Input: %#v\nExpected ErrNoInverse, got %v",
+				args, err)
+		}
+	}
+
+	// Random inverses multiply back to 1
+	for i := 0; i < 1000; i++ {
+		m := rand.Uint64() | 1
+		a := rand.Uint64()
+		x, err := InvMod(a, m)
+		if GCD(a, m) != 1 {
+			if err != ErrNoInverse {
+				t.Fatalf("Input: %#v\nExpected ErrNoInverse", Args{a, m})
+			}
+			continue
+		}
+		if err != nil || MulMod(a, x, m) != 1 {
+			t.Fatalf("Input: %#v\nGot: %v, %v", Args{a, m}, x, err)
+		}
+	}
+}
+
+func TestGCDLCM(t *testing.T) {
+	type Args struct {
+		a, b uint64
+	}
+
+	type Pair struct {
+		input    Args
+		gcd, lcm uint64
+	}
+
+	pairs := []Pair{{Args{0, 0}, 0, 0}, {Args{0, 5}, 5, 0},
+		{Args{12, 18}, 6, 36}, {Args{7, 13}, 1, 91},
+		{Args{1 << 63, 1 << 62}, 1 << 62, 1 << 63}}
+	for _, p := range pairs {
+		gcd := GCD(p.input.a, p.input.b)
+		lcm, err := LCM(p.input.a, p.input.b)
+		if err != nil || p.gcd != gcd || p.lcm != lcm {
+			t.Fatalf("Input: %#v\nExpected: %v, %v\n     Got: %v, %v, %v\n",
+				p.input, p.gcd, p.lcm, gcd, lcm, err)
+		}
+	}
+
+	if _, err := LCM(1<<32+1, 1<<32+3); err != ErrRange {
+		t.Fatalf("Expected ErrRange, got %v", err)
+	}
+}
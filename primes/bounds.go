@@ -0,0 +1,36 @@
+package primes
+
+import "math"
+
+// NthPrimeUpperBound returns a number the nth prime (counting 2 as the
+// first) is no greater than, so sieving up to it is sure to find n primes.
+// From n = 6 on, it is Rosser and Schoenfeld's bound, n (ln n + ln ln n),
+// which is within a few percent for large n.
+func NthPrimeUpperBound(n uint64) uint64 {
+	if n < 6 {
+		// The 5th prime
+		return 11
+	}
+	x := float64(n)
+	bound := math.Ceil(x * (math.Log(x) + math.Log(math.Log(x))))
+	// Past 2**64 there's nothing bigger to give
+	if bound >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	// Adding a little covers any float error
+	return uint64(bound) + 1
+}
+
+// NthPrimeLowerBound returns a number the nth prime is no less than.  From
+// n = 2 on, it is Dusart's bound, n (ln n + ln ln n - 1).
+func NthPrimeLowerBound(n uint64) uint64 {
+	if n < 2 {
+		return 2
+	}
+	x := float64(n)
+	bound := x * (math.Log(x) + math.Log(math.Log(x)) - 1)
+	if bound >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(max(math.Floor(bound), 2))
+}
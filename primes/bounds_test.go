@@ -0,0 +1,30 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNthPrimeBounds(t *testing.T) {
+	n := uint64(0)
+	for p := range Range(0, 2e6) {
+		n++
+		lower, upper := NthPrimeLowerBound(n), NthPrimeUpperBound(n)
+		if p < lower || p > upper {
+			t.Fatalf("Prime %v is %v, outside of %v to %v",
+				n, p, lower, upper)
+		}
+	}
+
+	// The 10**8th prime is 2038074743
+	lower, upper := NthPrimeLowerBound(1e8), NthPrimeUpperBound(1e8)
+	if lower > 2038074743 || upper < 2038074743 ||
+		float64(upper) > 1.05*2038074743 {
+		t.Fatalf("Expected close bounds around 2038074743, got %v to %v",
+			lower, upper)
+	}
+
+	if NthPrimeUpperBound(math.MaxUint64) != math.MaxUint64 {
+		t.Fatal("Expected the bound to stop at the largest uint64")
+	}
+}
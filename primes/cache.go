@@ -0,0 +1,200 @@
+package primes
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+)
+
+// CACHE_ENV names the environment variable holding the directory where
+// sieves are cached between runs.  Nothing is cached when it's unset.
+const CACHE_ENV = "PRIMES_CACHE"
+
+// CACHE_FILE is the name of the cached sieve within that directory.
+const CACHE_FILE = "sieve.bin"
+
+// CACHE_LIMIT_MIN and CACHE_LIMIT_MAX bound how far a cached sieve goes.
+// The largest takes 64 MiB on disk.
+const (
+	CACHE_LIMIT_MIN = 1 << 20
+	CACHE_LIMIT_MAX = 1 << 30
+)
+
+// A cached sieve starts with a fixed header, all little-endian:
+//
+//	magic    8 bytes  "PRIMSIEV"
+//	version  uint32   CACHE_VERSION
+//	checksum uint32   CRC-32 (IEEE) of everything after it
+//	limit    uint64   the sieve's limit
+//	words    uint64   how many uint64 words of bitset follow
+//
+// and then the bitset itself, in the same layout as Sieve.composite.  The
+// header is a multiple of 8 bytes so the bitset can be used in place.
+const (
+	CACHE_MAGIC       = "PRIMSIEV"
+	CACHE_VERSION     = 1
+	CACHE_HEADER_SIZE = 32
+)
+
+// ErrBadCache is returned for a cached sieve that is truncated, corrupt or
+// from another version.
+var ErrBadCache = errors.New("primes: bad sieve cache")
+
+// WriteSieve writes s to w in the cache format.
+func WriteSieve(w io.Writer, s *Sieve) error {
+	header := make([]byte, CACHE_HEADER_SIZE)
+	copy(header, CACHE_MAGIC)
+	binary.LittleEndian.PutUint32(header[8:], CACHE_VERSION)
+	binary.LittleEndian.PutUint64(header[16:], s.limit)
+	binary.LittleEndian.PutUint64(header[24:], uint64(len(s.composite)))
+	body := make([]byte, 0, 8*len(s.composite))
+	for _, word := range s.composite {
+		body = binary.LittleEndian.AppendUint64(body, word)
+	}
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[16:])
+	crc.Write(body)
+	binary.LittleEndian.PutUint32(header[12:], crc.Sum32())
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+// decodeSieve checks data is a whole cached sieve and returns it.  The
+// bitset is used in place when it's aligned and the machine is
+// little-endian, so data must not change afterwards.
+func decodeSieve(data []byte) (*Sieve, error) {
+	if len(data) < CACHE_HEADER_SIZE || string(data[:8]) != CACHE_MAGIC {
+		return nil, fmt.Errorf("%w: not a sieve", ErrBadCache)
+	}
+	if v := binary.LittleEndian.Uint32(data[8:]); v != CACHE_VERSION {
+		return nil, fmt.Errorf("%w: version %v, expected %v", ErrBadCache,
+			v, CACHE_VERSION)
+	}
+	limit := binary.LittleEndian.Uint64(data[16:])
+	words := binary.LittleEndian.Uint64(data[24:])
+	odds := limit/2 + limit%2
+	body := data[CACHE_HEADER_SIZE:]
+	if words != (odds+63)/64 || uint64(len(body)) != 8*words {
+		return nil, fmt.Errorf("%w: size doesn't match limit %v",
+			ErrBadCache, limit)
+	}
+	if crc32.ChecksumIEEE(data[16:]) != binary.LittleEndian.Uint32(data[12:]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrBadCache)
+	}
+
+	s := &Sieve{limit: limit}
+	if words == 0 {
+		return s, nil
+	}
+	if binary.NativeEndian.Uint16([]byte{1, 0}) == 1 &&
+		uintptr(unsafe.Pointer(&body[0]))%8 == 0 {
+		s.composite = unsafe.Slice((*uint64)(unsafe.Pointer(&body[0])), words)
+		return s, nil
+	}
+	s.composite = make([]uint64, words)
+	for i := range s.composite {
+		s.composite[i] = binary.LittleEndian.Uint64(body[8*i:])
+	}
+	return s, nil
+}
+
+// ReadSieve reads a sieve written by WriteSieve.
+func ReadSieve(r io.Reader) (*Sieve, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSieve(data)
+}
+
+// SaveSieve writes s to the file at path.  It writes to a temporary file
+// first and renames it, so a reader never sees half a sieve.
+func SaveSieve(path string, s *Sieve) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := WriteSieve(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadSieve reads the sieve in the file at path.  Where it can, it maps the
+// file into memory rather than reading it, and the mapping lasts as long as
+// the program, unless the file turns out not to be a sieve.
+func LoadSieve(path string) (*Sieve, error) {
+	data, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := decodeSieve(data)
+	if err != nil {
+		unmapFile(data)
+		return nil, err
+	}
+	return s, nil
+}
+
+// cache is the sieve shared by everything in the program that looks up
+// primes, loaded from CACHE_ENV the first time it's wanted.
+var cache struct {
+	sync.Mutex
+	loaded bool
+	sieve  *Sieve
+}
+
+// Cached returns a sieve covering as much of 0 to hi as the cache can, or
+// nil if CACHE_ENV isn't set.
+//
+// The first call loads the sieve saved by an earlier run.  If that doesn't
+// reach hi, and hi is no more than CACHE_LIMIT_MAX, it sieves further with
+// NewParallelSieve and saves the result for next time.  Failing to load or
+// save only costs time, so it isn't an error.
+func Cached(hi uint64) *Sieve {
+	dir := os.Getenv(CACHE_ENV)
+	if dir == "" {
+		return nil
+	}
+	path := filepath.Join(dir, CACHE_FILE)
+
+	cache.Lock()
+	defer cache.Unlock()
+	if !cache.loaded {
+		cache.loaded = true
+		cache.sieve, _ = LoadSieve(path)
+	}
+	if hi > CACHE_LIMIT_MAX || cache.sieve.covers(hi) {
+		return cache.sieve
+	}
+
+	// Grow at least geometrically, so creeping up doesn't resieve every
+	// time
+	limit := max(hi, CACHE_LIMIT_MIN)
+	if cache.sieve != nil {
+		limit = max(limit, 2*cache.sieve.limit)
+	}
+	cache.sieve = NewParallelSieve(min(limit, CACHE_LIMIT_MAX), 0)
+	os.MkdirAll(dir, 0o755)
+	SaveSieve(path, cache.sieve)
+	return cache.sieve
+}
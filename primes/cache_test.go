@@ -0,0 +1,143 @@
+package primes
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// resetCache forgets the cached sieve, as if the program just started.
+func resetCache(t *testing.T) {
+	reset := func() {
+		cache.Lock()
+		cache.loaded, cache.sieve = false, nil
+		cache.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestWriteReadSieve(t *testing.T) {
+	for _, limit := range []uint64{0, 1, 2, 100, 1e6 + 1} {
+		s := NewSieve(limit)
+		buf := &bytes.Buffer{}
+		if err := WriteSieve(buf, s); err != nil {
+			t.Fatal(err)
+		}
+		result, err := ReadSieve(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.limit != result.limit ||
+			!slices.Equal(s.composite, result.composite) {
+			t.Fatalf("Input: %v\nSieve changed on the way back", limit)
+		}
+	}
+
+	// Anything that's changed or cut short is refused
+	buf := &bytes.Buffer{}
+	WriteSieve(buf, NewSieve(1000))
+	data := buf.Bytes()
+	damage := map[string]func([]byte) []byte{
+		"empty":     func(d []byte) []byte { return d[:0] },
+		"truncated": func(d []byte) []byte { return d[:len(d)-8] },
+		"magic":     func(d []byte) []byte { d[0] = 'X'; return d },
+		"version":   func(d []byte) []byte { d[8]++; return d },
+		"limit":     func(d []byte) []byte { d[16]++; return d },
+		"bitset":    func(d []byte) []byte { d[len(d)-1] ^= 1; return d },
+	}
+	for name, f := range damage {
+		_, err := ReadSieve(bytes.NewReader(f(slices.Clone(data))))
+		if !errors.Is(err, ErrBadCache) {
+			t.Fatalf("Input: %v\nExpected ErrBadCache, got %v", name, err)
+		}
+	}
+}
+
+func TestSaveLoadSieve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CACHE_FILE)
+	s := NewSieve(3e6)
+	if err := SaveSieve(path, s); err != nil {
+		t.Fatal(err)
+	}
+	result, err := LoadSieve(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.limit != result.limit || !slices.Equal(s.composite, result.composite) {
+		t.Fatalf("Sieve changed on the way back")
+	}
+	if _, err := LoadSieve(path + ".missing"); err == nil {
+		t.Fatalf("Expected an error for a missing file")
+	}
+
+	// A file that isn't a sieve is let go of again
+	bad := filepath.Join(t.TempDir(), "bad")
+	if err := os.WriteFile(bad, []byte("not a sieve"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSieve(bad); !errors.Is(err, ErrBadCache) {
+		t.Fatalf("Expected ErrBadCache, got %v", err)
+	}
+}
+
+func TestCached(t *testing.T) {
+	resetCache(t)
+	t.Setenv(CACHE_ENV, "")
+	if s := Cached(100); s != nil {
+		t.Fatalf("Expected no cache, got a sieve up to %v", s.limit)
+	}
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	t.Setenv(CACHE_ENV, dir)
+	s := Cached(100)
+	if s.Limit() != CACHE_LIMIT_MIN {
+		t.Fatalf("Expected a sieve up to %v, got %v", CACHE_LIMIT_MIN,
+			s.Limit())
+	}
+	if _, err := os.Stat(filepath.Join(dir, CACHE_FILE)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh start loads it, past the limit doesn't resieve, and below the
+	// max grows it
+	resetCache(t)
+	if s := Cached(1000); s.Limit() != CACHE_LIMIT_MIN {
+		t.Fatalf("Expected the saved sieve, got one up to %v", s.Limit())
+	}
+	if s := Cached(CACHE_LIMIT_MAX + 1); s.Limit() != CACHE_LIMIT_MIN {
+		t.Fatalf("Expected the saved sieve, got one up to %v", s.Limit())
+	}
+	if s := Cached(CACHE_LIMIT_MIN + 1); s.Limit() != 2*CACHE_LIMIT_MIN {
+		t.Fatalf("Expected a sieve up to %v, got %v", 2*CACHE_LIMIT_MIN,
+			s.Limit())
+	}
+
+	// Ranges give the same primes whether or not they come from the cache,
+	// including ones that run past it
+	for _, args := range [][2]uint64{{0, 1000}, {999983, 1000100},
+		{2*CACHE_LIMIT_MIN - 1000, 2*CACHE_LIMIT_MIN + 1000}} {
+		cached := slices.Collect(Range(args[0], args[1]))
+		parallel := slices.Collect(ParallelRange(args[0], args[1], 2))
+		t.Setenv(CACHE_ENV, "")
+		expected := slices.Collect(Range(args[0], args[1]))
+		t.Setenv(CACHE_ENV, dir)
+		if !slices.Equal(expected, cached) || !slices.Equal(expected, parallel) {
+			t.Fatalf("Input: %v\nExpected: %v\n     Got: %v, %v\n", args,
+				expected, cached, parallel)
+		}
+	}
+
+	// A damaged cache is sieved again and replaced
+	resetCache(t)
+	os.WriteFile(filepath.Join(dir, CACHE_FILE), []byte("junk"), 0o644)
+	if s := Cached(100); s.Limit() != CACHE_LIMIT_MIN {
+		t.Fatalf("Expected a new sieve, got one up to %v", s.Limit())
+	}
+	if _, err := LoadSieve(filepath.Join(dir, CACHE_FILE)); err != nil {
+		t.Fatal(err)
+	}
+}
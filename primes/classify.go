@@ -0,0 +1,267 @@
+package primes
+
+import (
+	"iter"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+// Gaps between the two primes of a twin, cousin and sexy pair
+const (
+	TWIN_GAP   = 2
+	COUSIN_GAP = 4
+	SEXY_GAP   = 6
+)
+
+// filter yields the primes from lo to hi that pass keep.
+func filter(lo, hi uint64, keep func(uint64) bool) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		for p := range Range(lo, hi) {
+			if keep(p) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// reverseDigits returns n with its decimal digits reversed, and false if
+// that doesn't fit in a uint64.
+func reverseDigits(n uint64) (uint64, bool) {
+	rv := uint64(0)
+	for ; n > 0; n /= 10 {
+		hi, lo := bits.Mul64(rv, 10)
+		sum, carry := bits.Add64(lo, n%10, 0)
+		if hi != 0 || carry != 0 {
+			return 0, false
+		}
+		rv = sum
+	}
+	return rv, true
+}
+
+// IsEmirp reports whether n is a prime that gives a different prime when
+// its decimal digits are reversed.
+func IsEmirp(n uint64) bool {
+	r, ok := reverseDigits(n)
+	return ok && r != n && IsPrime(n) && IsPrime(r)
+}
+
+// Emirps iterates over the emirps from lo to hi.
+func Emirps(lo, hi uint64) iter.Seq[uint64] {
+	return filter(lo, hi, func(p uint64) bool {
+		r, ok := reverseDigits(p)
+		return ok && r != p && IsPrime(r)
+	})
+}
+
+// PrimePairs iterates over the pairs of primes p and p+gap with p from lo to
+// hi.  The primes between them don't matter, so with a gap of 6, 5 and 11
+// are a pair even though 7 is prime.
+func PrimePairs(lo, hi, gap uint64) iter.Seq2[uint64, uint64] {
+	return func(yield func(uint64, uint64) bool) {
+		if gap == 0 {
+			return
+		}
+		// The primes within gap of the newest one, oldest first
+		recent := []uint64{}
+		top := hi + gap
+		if top < hi {
+			top = math.MaxUint64
+		}
+		for q := range Range(lo, top) {
+			for len(recent) > 0 && recent[0]+gap < q {
+				recent = recent[1:]
+			}
+			if len(recent) > 0 && recent[0]+gap == q {
+				if !yield(recent[0], q) {
+					return
+				}
+			}
+			recent = append(recent, q)
+		}
+	}
+}
+
+// TwinPrimes iterates over the pairs p, p+2 with p from lo to hi.
+func TwinPrimes(lo, hi uint64) iter.Seq2[uint64, uint64] {
+	return PrimePairs(lo, hi, TWIN_GAP)
+}
+
+// CousinPrimes iterates over the pairs p, p+4 with p from lo to hi.
+func CousinPrimes(lo, hi uint64) iter.Seq2[uint64, uint64] {
+	return PrimePairs(lo, hi, COUSIN_GAP)
+}
+
+// SexyPrimes iterates over the pairs p, p+6 with p from lo to hi.
+func SexyPrimes(lo, hi uint64) iter.Seq2[uint64, uint64] {
+	return PrimePairs(lo, hi, SEXY_GAP)
+}
+
+// isCircular reports whether every rotation of prime p's decimal digits
+// is prime too.
+func isCircular(p uint64) bool {
+	// Past one digit, an even digit or 5 ends up last in some rotation
+	if p >= 10 {
+		for n := p; n > 0; n /= 10 {
+			switch n % 10 {
+			case 0, 2, 4, 5, 6, 8:
+				return false
+			}
+		}
+	}
+	top := uint64(1)
+	for top <= p/10 {
+		top *= 10
+	}
+	for r := p; ; {
+		// Move the last digit to the front
+		hi, lo := bits.Mul64(r%10, top)
+		sum, carry := bits.Add64(lo, r/10, 0)
+		if hi != 0 || carry != 0 {
+			return false
+		}
+		if r = sum; r == p {
+			return true
+		}
+		if !IsPrime(r) {
+			return false
+		}
+	}
+}
+
+// IsCircular reports whether n and every rotation of its decimal digits are
+// prime, like 197, 971 and 719.
+func IsCircular(n uint64) bool {
+	return IsPrime(n) && isCircular(n)
+}
+
+// CircularPrimes iterates over the circular primes from lo to hi.
+func CircularPrimes(lo, hi uint64) iter.Seq[uint64] {
+	return filter(lo, hi, isCircular)
+}
+
+// truncatable holds every truncatable prime that fits in a uint64.  There
+// are only a few thousand, so they are grown digit by digit rather than
+// searched for.
+type truncatable struct {
+	// left stay prime as leading digits are dropped, and right as trailing
+	// ones are.  Neither has zeros, which would drop out of the number.
+	left, right []uint64
+}
+
+var truncatables = sync.OnceValue(func() truncatable {
+	grow := func(extend func(p, digit, place uint64) (uint64, bool)) []uint64 {
+		rv := []uint64{}
+		level := []uint64{2, 3, 5, 7}
+		for place := uint64(10); len(level) > 0; place *= 10 {
+			rv = append(rv, level...)
+			next := []uint64{}
+			for _, p := range level {
+				for d := uint64(1); d <= 9; d++ {
+					if n, ok := extend(p, d, place); ok && IsPrime(n) {
+						next = append(next, n)
+					}
+				}
+			}
+			level = next
+			// The place after this one wouldn't fit, so these are
+			// the last that can
+			if place > math.MaxUint64/10 {
+				rv = append(rv, level...)
+				break
+			}
+		}
+		slices.Sort(rv)
+		return rv
+	}
+	// a*b + c, if it fits
+	mulAdd := func(a, b, c uint64) (uint64, bool) {
+		hi, lo := bits.Mul64(a, b)
+		sum, carry := bits.Add64(lo, c, 0)
+		return sum, hi == 0 && carry == 0
+	}
+	return truncatable{
+		left: grow(func(p, d, place uint64) (uint64, bool) {
+			return mulAdd(d, place, p)
+		}),
+		right: grow(func(p, d, place uint64) (uint64, bool) {
+			return mulAdd(p, 10, d)
+		}),
+	}
+})
+
+// inSorted iterates over the numbers in sorted s from lo to hi.
+func inSorted(s []uint64, lo, hi uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		i, _ := slices.BinarySearch(s, lo)
+		for ; i < len(s) && s[i] <= hi; i++ {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
+
+// IsLeftTruncatable reports whether n is prime, has no zero digits, and stays
+// prime as its leading digits are dropped one by one, like 9137, 137, 37, 7.
+func IsLeftTruncatable(n uint64) bool {
+	_, found := slices.BinarySearch(truncatables().left, n)
+	return found
+}
+
+// IsRightTruncatable reports whether n is prime and stays prime as its
+// trailing digits are dropped one by one, like 7393, 739, 73, 7.
+func IsRightTruncatable(n uint64) bool {
+	_, found := slices.BinarySearch(truncatables().right, n)
+	return found
+}
+
+// LeftTruncatablePrimes iterates over the left-truncatable primes from lo to
+// hi.
+func LeftTruncatablePrimes(lo, hi uint64) iter.Seq[uint64] {
+	return inSorted(truncatables().left, lo, hi)
+}
+
+// RightTruncatablePrimes iterates over the right-truncatable primes from lo
+// to hi.
+func RightTruncatablePrimes(lo, hi uint64) iter.Seq[uint64] {
+	return inSorted(truncatables().right, lo, hi)
+}
+
+// TruncatablePrimes iterates over the primes from lo to hi that are both
+// left- and right-truncatable, like 3797.
+func TruncatablePrimes(lo, hi uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		for p := range RightTruncatablePrimes(lo, hi) {
+			if IsLeftTruncatable(p) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// IsSophieGermain reports whether n and 2n+1 are both prime.
+func IsSophieGermain(n uint64) bool {
+	return n <= (math.MaxUint64-1)/2 && IsPrime(n) && IsPrime(2*n+1)
+}
+
+// IsSafe reports whether n and (n-1)/2 are both prime.
+func IsSafe(n uint64) bool {
+	return n >= 5 && IsPrime(n) && IsPrime((n-1)/2)
+}
+
+// SophieGermainPrimes iterates over the Sophie Germain primes from lo to hi.
+func SophieGermainPrimes(lo, hi uint64) iter.Seq[uint64] {
+	return filter(lo, hi, func(p uint64) bool {
+		return p <= (math.MaxUint64-1)/2 && IsPrime(2*p+1)
+	})
+}
+
+// SafePrimes iterates over the safe primes from lo to hi.
+func SafePrimes(lo, hi uint64) iter.Seq[uint64] {
+	return filter(lo, hi, func(p uint64) bool {
+		return p >= 5 && IsPrime((p-1)/2)
+	})
+}
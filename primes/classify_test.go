@@ -0,0 +1,189 @@
+package primes
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// collectPairs gathers the pairs from a PrimePairs style iterator.
+func collectPairs(seq func(func(uint64, uint64) bool)) [][2]uint64 {
+	rv := [][2]uint64{}
+	for p, q := range seq {
+		rv = append(rv, [2]uint64{p, q})
+	}
+	return rv
+}
+
+func TestClassifiers(t *testing.T) {
+	type Pair struct {
+		name     string
+		input    []uint64
+		expected []uint64
+	}
+
+	pairs := []Pair{
+		{"Emirps", slices.Collect(Emirps(0, 100)),
+			[]uint64{13, 17, 31, 37, 71, 73, 79, 97}},
+		// Most primes this high reverse to something past 2**64
+		{"Emirps near 2**64", slices.Collect(
+			Emirps(math.MaxUint64-1000, math.MaxUint64)),
+			[]uint64{18446744073709550771}},
+		{"CircularPrimes", slices.Collect(CircularPrimes(0, 100)),
+			[]uint64{2, 3, 5, 7, 11, 13, 17, 31, 37, 71, 73, 79, 97}},
+		{"SophieGermainPrimes", slices.Collect(SophieGermainPrimes(0, 100)),
+			[]uint64{2, 3, 5, 11, 23, 29, 41, 53, 83, 89}},
+		{"SafePrimes", slices.Collect(SafePrimes(0, 100)),
+			[]uint64{5, 7, 11, 23, 47, 59, 83}},
+		{"TruncatablePrimes", slices.Collect(
+			TruncatablePrimes(0, math.MaxUint64)),
+			[]uint64{2, 3, 5, 7, 23, 37, 53, 73, 313, 317, 373, 797, 3137,
+				3797, 739397}},
+		{"RightTruncatablePrimes", slices.Collect(
+			RightTruncatablePrimes(1e7, math.MaxUint64)),
+			[]uint64{23399339, 29399999, 37337999, 59393339, 73939133}},
+		{"LeftTruncatablePrimes", slices.Collect(
+			LeftTruncatablePrimes(0, 50)),
+			[]uint64{2, 3, 5, 7, 13, 17, 23, 37, 43, 47}},
+		{"MersenneExponents", slices.Collect(MersenneExponents(0, 1300)),
+			[]uint64{2, 3, 5, 7, 13, 17, 19, 31, 61, 89, 107, 127, 521,
+				607, 1279}},
+		{"MersennePrimes", slices.Collect(MersennePrimes(0, math.MaxUint64)),
+			[]uint64{3, 7, 31, 127, 8191, 131071, 524287, 2147483647,
+				2305843009213693951}},
+	}
+	for _, p := range pairs {
+		if !reflect.DeepEqual(p.expected, p.input) {
+			t.Fatalf("Input: %v\nExpected: %#v\n     Got: %#v\n",
+				p.name, p.expected, p.input)
+		}
+	}
+
+	if n := len(truncatables().right); n != 83 {
+		t.Fatalf("Expected 83 right-truncatable primes, got %v", n)
+	}
+	// 4242 of the 4260 left-truncatable primes fit in a uint64, the
+	// largest with 20 digits
+	left := truncatables().left
+	if n := len(left); n != 4242 {
+		t.Fatalf("Expected 4242 left-truncatable primes, got %v", n)
+	}
+	if largest := left[len(left)-1]; largest != 15396334245663786197 {
+		t.Fatalf("Expected 15396334245663786197 as the largest "+
+			"left-truncatable prime, got %v", largest)
+	}
+	count := 0
+	for range CircularPrimes(0, 1e6) {
+		count++
+	}
+	if count != 55 {
+		t.Fatalf("Expected 55 circular primes below 10**6, got %v", count)
+	}
+}
+
+func TestPrimePairs(t *testing.T) {
+	type Pair struct {
+		name     string
+		input    [][2]uint64
+		expected [][2]uint64
+	}
+
+	pairs := []Pair{
+		{"TwinPrimes", collectPairs(TwinPrimes(0, 71)),
+			[][2]uint64{{3, 5}, {5, 7}, {11, 13}, {17, 19}, {29, 31},
+				{41, 43}, {59, 61}, {71, 73}}},
+		{"CousinPrimes", collectPairs(CousinPrimes(5, 50)),
+			[][2]uint64{{7, 11}, {13, 17}, {19, 23}, {37, 41}, {43, 47}}},
+		{"SexyPrimes", collectPairs(SexyPrimes(0, 31)),
+			[][2]uint64{{5, 11}, {7, 13}, {11, 17}, {13, 19}, {17, 23},
+				{23, 29}, {31, 37}}},
+		// The last twin pairs below 2**64, where hi+gap would wrap around
+		{"TwinPrimes near 2**64", collectPairs(
+			TwinPrimes(math.MaxUint64-1000, math.MaxUint64)),
+			[][2]uint64{{18446744073709550717, 18446744073709550719},
+				{18446744073709550771, 18446744073709550773}}},
+		{"PrimePairs gap 0", collectPairs(PrimePairs(0, 100, 0)),
+			[][2]uint64{}},
+	}
+	for _, p := range pairs {
+		if !reflect.DeepEqual(p.expected, p.input) {
+			t.Fatalf("Input: %v\nExpected: %#v\n     Got: %#v\n",
+				p.name, p.expected, p.input)
+		}
+	}
+}
+
+func TestIsClassified(t *testing.T) {
+	// Each Is function agrees with its iterator
+	type Pair struct {
+		name string
+		is   func(uint64) bool
+		seq  func(lo, hi uint64) func(func(uint64) bool)
+	}
+
+	pairs := []Pair{
+		{"Emirp", IsEmirp, func(lo, hi uint64) func(func(uint64) bool) {
+			return Emirps(lo, hi)
+		}},
+		{"Circular", IsCircular, func(lo, hi uint64) func(func(uint64) bool) {
+			return CircularPrimes(lo, hi)
+		}},
+		{"LeftTruncatable", IsLeftTruncatable,
+			func(lo, hi uint64) func(func(uint64) bool) {
+				return LeftTruncatablePrimes(lo, hi)
+			}},
+		{"RightTruncatable", IsRightTruncatable,
+			func(lo, hi uint64) func(func(uint64) bool) {
+				return RightTruncatablePrimes(lo, hi)
+			}},
+		{"SophieGermain", IsSophieGermain,
+			func(lo, hi uint64) func(func(uint64) bool) {
+				return SophieGermainPrimes(lo, hi)
+			}},
+		{"Safe", IsSafe, func(lo, hi uint64) func(func(uint64) bool) {
+			return SafePrimes(lo, hi)
+		}},
+		{"MersennePrime", IsMersennePrime,
+			func(lo, hi uint64) func(func(uint64) bool) {
+				return MersennePrimes(lo, hi)
+			}},
+	}
+	for _, p := range pairs {
+		lo := uint64(rand.Intn(1e6))
+		hi := lo + 1e4
+		expected := []uint64{}
+		for n := lo; n <= hi; n++ {
+			if p.is(n) {
+				expected = append(expected, n)
+			}
+		}
+		result := []uint64{}
+		for n := range p.seq(lo, hi) {
+			result = append(result, n)
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Input: %v %v..%v\nExpected: %#v\n     Got: %#v\n",
+				p.name, lo, hi, expected, result)
+		}
+	}
+
+	// Left-truncatable primes checked the slow way
+	for n := uint64(0); n < 1e5; n++ {
+		expected := true
+		for place := uint64(10); ; place *= 10 {
+			if !IsPrime(n%place) || n%place < place/10 {
+				expected = false
+				break
+			}
+			if n < place {
+				break
+			}
+		}
+		if result := IsLeftTruncatable(n); expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				n, expected, result)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package primes
+
+import (
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+// TRIAL_DIVISION_MAX is the largest prime Factor divides by directly before
+// it switches to Pollard-Rho.
+const TRIAL_DIVISION_MAX = 1 << 12
+
+// BRENT_BATCH is how many differences Pollard-Rho multiplies together before
+// taking a gcd.
+const BRENT_BATCH = 128
+
+// trialPrimes are the primes up to TRIAL_DIVISION_MAX, sieved once.
+var trialPrimes = sync.OnceValue(func() []uint64 {
+	rv := []uint64{}
+	for p := range NewSieve(TRIAL_DIVISION_MAX).Primes() {
+		rv = append(rv, p)
+	}
+	return rv
+})
+
+// PrimePower is one prime factor P of a number, which divides it K times.
+type PrimePower struct {
+	P uint64
+	K int
+}
+
+// Factor returns the prime factorisation of n, smallest prime first.  0 and
+// 1 have no prime factors.
+//
+// Small factors are found by trial division.  What's left is split with
+// Pollard-Rho until Miller-Rabin says every piece is prime, which takes
+// about n**1/4 steps for the hardest n.
+func Factor(n uint64) []PrimePower {
+	rv := []PrimePower{}
+	if n < 2 {
+		return rv
+	}
+	for _, p := range trialPrimes() {
+		if p*p > n {
+			break
+		}
+		if n%p == 0 {
+			k := 0
+			for ; n%p == 0; n /= p {
+				k++
+			}
+			rv = append(rv, PrimePower{p, k})
+		}
+	}
+	if n == 1 {
+		return rv
+	}
+
+	// Anything left has no factor up to TRIAL_DIVISION_MAX
+	large := []uint64{}
+	pending := []uint64{n}
+	for len(pending) > 0 {
+		m := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if IsPrime(m) {
+			large = append(large, m)
+			continue
+		}
+		d := pollardBrent(m)
+		pending = append(pending, d, m/d)
+	}
+	slices.Sort(large)
+	for _, p := range large {
+		if last := len(rv) - 1; last >= 0 && rv[last].P == p {
+			rv[last].K++
+		} else {
+			rv = append(rv, PrimePower{p, 1})
+		}
+	}
+	return rv
+}
+
+// pollardBrent returns a factor of n other than 1 and n.  n must be an odd
+// composite.
+//
+// The sequence y -> y**2 + c mod n repeats mod each prime factor p long
+// before it repeats mod n, and a repeat shows up as a difference that shares
+// p with n.  Brent's variant finds the cycle by comparing against a saved
+// point that moves at powers of 2, and takes the gcd of a batch of
+// differences at once.
+func pollardBrent(n uint64) uint64 {
+	// A perfect square might cycle mod p and mod n at the same time for
+	// every c
+	if r := isqrt(n); r*r == n {
+		return r
+	}
+	diff := func(a, b uint64) uint64 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+	for c := uint64(1); ; c++ {
+		f := func(y uint64) uint64 {
+			return addMod(mulMod(y, y, n), c, n)
+		}
+		x, y, ys := uint64(2), uint64(2), uint64(2)
+		g, q := uint64(1), uint64(1)
+		for r := 1; g == 1; r *= 2 {
+			x = y
+			for i := 0; i < r; i++ {
+				y = f(y)
+			}
+			for k := 0; k < r && g == 1; k += BRENT_BATCH {
+				ys = y
+				for i := 0; i < min(BRENT_BATCH, r-k); i++ {
+					y = f(y)
+					q = mulMod(q, diff(x, y), n)
+				}
+				g = GCD(q, n)
+			}
+		}
+		// The batch overshot, so step through it again one at a time
+		if g == n {
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = GCD(diff(x, ys), n)
+			}
+		}
+		if g != n {
+			return g
+		}
+	}
+}
+
+// Totient returns Euler's phi(n), how many of 1..n are coprime to n.
+func Totient(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	rv := n
+	for _, pp := range Factor(n) {
+		rv = rv / pp.P * (pp.P - 1)
+	}
+	return rv
+}
+
+// DivisorCount returns how many divisors n has.  0 is taken to have none.
+func DivisorCount(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	rv := uint64(1)
+	for _, pp := range Factor(n) {
+		rv *= uint64(pp.K + 1)
+	}
+	return rv
+}
+
+// DivisorSum returns the sum of the divisors of n, or ErrRange if it doesn't
+// fit in a uint64.  0 is taken to have none.
+func DivisorSum(n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, nil
+	}
+	rv := uint64(1)
+	for _, pp := range Factor(n) {
+		// 1 + p + ... + p**k.  p**k divides n, but the sum can be up to
+		// twice that.
+		term, pk := uint64(1), uint64(1)
+		for i := 0; i < pp.K; i++ {
+			pk *= pp.P
+			var carry uint64
+			if term, carry = bits.Add64(term, pk, 0); carry != 0 {
+				return 0, ErrRange
+			}
+		}
+		hi, lo := bits.Mul64(rv, term)
+		if hi != 0 {
+			return 0, ErrRange
+		}
+		rv = lo
+	}
+	return rv, nil
+}
+
+// Mobius returns mu(n): 0 if a square divides n, otherwise 1 or -1 for an
+// even or odd number of prime factors.  mu(0) is taken to be 0.
+func Mobius(n uint64) int {
+	if n == 0 {
+		return 0
+	}
+	rv := 1
+	for _, pp := range Factor(n) {
+		if pp.K > 1 {
+			return 0
+		}
+		rv = -rv
+	}
+	return rv
+}
+
+// Divisors returns every divisor of n in increasing order.  0 is taken to
+// have none.
+func Divisors(n uint64) []uint64 {
+	if n == 0 {
+		return []uint64{}
+	}
+	rv := []uint64{1}
+	for _, pp := range Factor(n) {
+		size := len(rv)
+		pk := uint64(1)
+		for i := 0; i < pp.K; i++ {
+			pk *= pp.P
+			for _, d := range rv[:size] {
+				rv = append(rv, d*pk)
+			}
+		}
+	}
+	slices.Sort(rv)
+	return rv
+}
@@ -0,0 +1,144 @@
+package primes
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// slowFactor is trial division by everything up to the square root.
+func slowFactor(n uint64) []PrimePower {
+	rv := []PrimePower{}
+	for d := uint64(2); d*d <= n; d++ {
+		if n%d == 0 {
+			k := 0
+			for ; n%d == 0; n /= d {
+				k++
+			}
+			rv = append(rv, PrimePower{d, k})
+		}
+	}
+	if n > 1 {
+		rv = append(rv, PrimePower{n, 1})
+	}
+	return rv
+}
+
+func TestFactor(t *testing.T) {
+	type Pair struct {
+		input    uint64
+		expected []PrimePower
+	}
+
+	pairs := []Pair{{0, []PrimePower{}}, {1, []PrimePower{}},
+		{2, []PrimePower{{2, 1}}}, {360, []PrimePower{{2, 3}, {3, 2}, {5, 1}}},
+		{1<<64 - 1, []PrimePower{{3, 1}, {5, 1}, {17, 1}, {257, 1},
+			{641, 1}, {65537, 1}, {6700417, 1}}},
+		{1 << 63, []PrimePower{{2, 63}}},
+		{18446744073709551557, []PrimePower{{18446744073709551557, 1}}},
+		// Products of two primes near 2**32, and the square of one
+		{4294967291 * 4294967279,
+			[]PrimePower{{4294967279, 1}, {4294967291, 1}}},
+		{4294967291 * 4294967291, []PrimePower{{4294967291, 2}}},
+		{65537 * 65537 * 65537 * 4099, []PrimePower{{4099, 1}, {65537, 3}}},
+		// A Carmichael number
+		{561, []PrimePower{{3, 1}, {11, 1}, {17, 1}}},
+	}
+	for _, p := range pairs {
+		result := Factor(p.input)
+		if !reflect.DeepEqual(p.expected, result) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	// Against trial division
+	for i := 0; i < 1000; i++ {
+		n := uint64(rand.Int63n(1 << 40))
+		expected := slowFactor(n)
+		if result := Factor(n); !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				n, expected, result)
+		}
+	}
+
+	// Random products of two large primes multiply back to themselves
+	for i := 0; i < 50; i++ {
+		a := uint64(rand.Int63n(1<<31)) + 1<<31
+		b := uint64(rand.Int63n(1<<31)) + 1<<31
+		for !IsPrime(a) {
+			a++
+		}
+		for !IsPrime(b) {
+			b++
+		}
+		expected := []PrimePower{{min(a, b), 1}, {max(a, b), 1}}
+		if a == b {
+			expected = []PrimePower{{a, 2}}
+		}
+		if result := Factor(a * b); !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				a*b, expected, result)
+		}
+	}
+}
+
+func TestArithmeticFunctions(t *testing.T) {
+	type Result struct {
+		totient, count, sum uint64
+		mobius              int
+	}
+
+	type Pair struct {
+		input    uint64
+		expected Result
+	}
+
+	pairs := []Pair{{0, Result{0, 0, 0, 0}}, {1, Result{1, 1, 1, 1}},
+		{2, Result{1, 2, 3, -1}}, {12, Result{4, 6, 28, 0}},
+		{30, Result{8, 8, 72, -1}}, {97, Result{96, 2, 98, -1}},
+		{1 << 63, Result{1 << 62, 64, 1<<64 - 1, 0}}}
+	for _, p := range pairs {
+		sum, err := DivisorSum(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Result{Totient(p.input), DivisorCount(p.input), sum,
+			Mobius(p.input)}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	// The divisors of 2**64-1 add up past 2**64
+	if _, err := DivisorSum(1<<64 - 1); err != ErrRange {
+		t.Fatalf("Expected ErrRange, got %v", err)
+	}
+	if result := Totient(1<<64 - 1); result !=
+		2*4*16*256*640*65536*6700416 {
+		t.Fatalf("Expected 2*4*16*256*640*65536*6700416, got %v", result)
+	}
+
+	// Against the divisors themselves
+	for i := 0; i < 200; i++ {
+		n := uint64(rand.Intn(1e5)) + 1
+		divs := Divisors(n)
+		sum, coprime := uint64(0), uint64(0)
+		for d := uint64(1); d <= n; d++ {
+			if n%d == 0 {
+				sum += d
+			}
+			if GCD(d, n) == 1 {
+				coprime++
+			}
+		}
+		total, _ := DivisorSum(n)
+		if uint64(len(divs)) != DivisorCount(n) || sum != total ||
+			coprime != Totient(n) {
+			t.Fatalf("Input: %#v\nDivisors: %v\n     Sum: %v, %v\n"+
+				" Totient: %v, %v\n", n, divs, sum, total, coprime,
+				Totient(n))
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package primes
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrRange is returned when there is no answer that fits in a uint64.
+var ErrRange = errors.New("primes: argument out of range")
+
+// PI_TABLE_MAX caps how far PrimePi sieves to look up small counts.  At one
+// bit per odd number plus a count per word, that is 24 MiB.
+const PI_TABLE_MAX = 1 << 28
+
+// PHI_TABLE_PRIMES is how many of the smallest primes have their phi values
+// precomputed over a whole primorial (30030 numbers for the first 6).
+const PHI_TABLE_PRIMES = 6
+
+// NTH_PRIME_SIEVE_MAX is the n below which NthPrime just sieves.
+const NTH_PRIME_SIEVE_MAX = 1e6
+
+// piTable answers pi(y) for y up to its limit with a lookup and a popcount.
+type piTable struct {
+	*Sieve
+	// before[w] counts the odd numbers in words before w that aren't
+	// crossed off (1 included)
+	before []uint32
+	// The primes up to some smaller limit, so primes[i-1] is the ith
+	primes []uint64
+}
+
+// newPiTable sieves up to limit, and keeps a list of the primes up to
+// primesLimit.
+func newPiTable(limit, primesLimit uint64) *piTable {
+	t := &piTable{Sieve: NewSieve(limit)}
+	t.before = make([]uint32, len(t.composite)+1)
+	for w, word := range t.composite {
+		t.before[w+1] = t.before[w] + uint32(bits.OnesCount64(^word))
+	}
+	for p := range t.Primes() {
+		if p > primesLimit {
+			break
+		}
+		t.primes = append(t.primes, p)
+	}
+	return t
+}
+
+// pi returns how many primes there are up to y, which must be within the
+// limit.
+func (t *piTable) pi(y uint64) uint64 {
+	if y < 2 {
+		return 0
+	}
+	// 1 isn't prime, but it takes the place of 2 in the count
+	i := (y - 1) / 2
+	mask := ^uint64(0) >> (63 - i%64)
+	return uint64(t.before[i/64]) +
+		uint64(bits.OnesCount64(^t.composite[i/64]&mask))
+}
+
+// iroot returns the largest r with r**k <= n.
+func iroot(n uint64, k int) uint64 {
+	r := uint64(math.Pow(float64(n), 1/float64(k)))
+	fits := func(r uint64) bool {
+		p := uint64(1)
+		for i := 0; i < k; i++ {
+			hi, lo := bits.Mul64(p, r)
+			if hi != 0 {
+				return false
+			}
+			p = lo
+		}
+		return p <= n
+	}
+	for r > 0 && !fits(r) {
+		r--
+	}
+	for fits(r + 1) {
+		r++
+	}
+	return r
+}
+
+// lehmer holds what is shared between the recursive steps of PrimePi.
+type lehmer struct {
+	*piTable
+	// phiSmall[a][y] is phi(y, a) for y below the product of the first a
+	// primes
+	phiSmall [][]uint64
+}
+
+// newLehmer sets up to count primes up to x.
+func newLehmer(x uint64) *lehmer {
+	// Every prime up to sqrt(x) is needed for the sums, and counts up to
+	// x**2/3 can be looked up instead of worked out again
+	sqrt := max(isqrt(x)+1, 20)
+	limit := max(min(iroot(x, 3)*iroot(x, 3), PI_TABLE_MAX), sqrt)
+	l := &lehmer{piTable: newPiTable(limit, sqrt)}
+
+	l.phiSmall = make([][]uint64, PHI_TABLE_PRIMES+1)
+	l.phiSmall[0] = []uint64{0}
+	for a := 1; a <= PHI_TABLE_PRIMES; a++ {
+		p := l.primes[a-1]
+		primorial := uint64(len(l.phiSmall[a-1])) * p
+		table := make([]uint64, primorial)
+		for y := range table {
+			// phi(y, a) = phi(y, a-1) - phi(y/p, a-1)
+			table[y] = l.phiAt(uint64(y), a-1) - l.phiAt(uint64(y)/p, a-1)
+		}
+		l.phiSmall[a] = table
+	}
+	return l
+}
+
+// phiAt looks up phi(y, a) for a up to PHI_TABLE_PRIMES, using the table's
+// period for large y.
+func (l *lehmer) phiAt(y uint64, a int) uint64 {
+	if a == 0 {
+		return y
+	}
+	table := l.phiSmall[a]
+	period := uint64(len(table))
+	// Each full period has the same count, which is the count just
+	// below it
+	return y/period*table[period-1] + table[y%period]
+}
+
+// phi counts the numbers up to x that aren't divisible by any of the first
+// a primes.
+func (l *lehmer) phi(x uint64, a int) uint64 {
+	if a <= PHI_TABLE_PRIMES {
+		return l.phiAt(x, a)
+	}
+	// Only 1 and primes are left once p_a**2 > x
+	if x <= l.limit && x < l.primes[a-1]*l.primes[a-1] {
+		if x < l.primes[a-1] {
+			return min(x, 1)
+		}
+		return l.pi(x) - uint64(a) + 1
+	}
+
+	// phi(x, a) = phi(x, a-1) - phi(x/p_a, a-1), unrolled all the way
+	// down to the table
+	rv := l.phiAt(x, PHI_TABLE_PRIMES)
+	for i := PHI_TABLE_PRIMES; i < a; i++ {
+		rv -= l.phi(x/l.primes[i], i)
+	}
+	return rv
+}
+
+// count is Lehmer's formula for pi(x).  With a = pi(x**1/4), b = pi(x**1/2)
+// and c = pi(x**1/3), every number up to x without a prime factor below
+// p_a is 1, a prime, or a product of two or three bigger primes:
+//
+//	pi(x) = phi(x, a) + (b+a-2)(b-a+1)/2 - sum(a < i <= b) pi(x/p_i)
+//	        - sum(a < i <= c) sum(i <= j <= pi(sqrt(x/p_i)))
+//	          (pi(x/p_i/p_j) - (j-1))
+func (l *lehmer) count(x uint64) uint64 {
+	if x <= l.limit {
+		return l.pi(x)
+	}
+
+	a := l.count(iroot(x, 4))
+	b := l.count(isqrt(x))
+	c := l.count(iroot(x, 3))
+
+	// Subtracting as it goes could dip below 0 for a moment
+	sum := int64(l.phi(x, int(a))) + int64((b+a-2)*(b-a+1)/2)
+	for i := a + 1; i <= b; i++ {
+		w := x / l.primes[i-1]
+		sum -= int64(l.count(w))
+		if i > c {
+			continue
+		}
+		bi := l.count(isqrt(w))
+		for j := i; j <= bi; j++ {
+			sum -= int64(l.count(w/l.primes[j-1])) - int64(j-1)
+		}
+	}
+	return uint64(sum)
+}
+
+// PrimePi counts the primes up to x with the Meissel-Lehmer method.  It
+// takes about O(x**2/3) time and space rather than sieving all the way to
+// x, so it's practical well past 10**12.
+func PrimePi(x uint64) uint64 {
+	if x < 2 {
+		return 0
+	}
+	return newLehmer(x).count(x)
+}
+
+// NthPrime returns the nth prime, counting 2 as the first.  For large n, it
+// counts the primes up to an estimate with PrimePi, then sieves from there
+// to the answer.  It returns ErrRange if n is 0 or the nth prime doesn't
+// fit in a uint64.
+func NthPrime(n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, ErrRange
+	}
+	if n < NTH_PRIME_SIEVE_MAX {
+		count := uint64(0)
+		for p := range Range(0, NthPrimeUpperBound(n)) {
+			if count++; count == n {
+				return p, nil
+			}
+		}
+	}
+
+	// Cipolla's estimate, which is well inside the bounds
+	ln := math.Log(float64(n))
+	lnln := math.Log(ln)
+	est := float64(n) * (ln + lnln - 1 + (lnln-2)/ln)
+	if est >= math.MaxUint64 {
+		return 0, ErrRange
+	}
+	x := uint64(est)
+	count := PrimePi(x)
+
+	if count < n {
+		for p := range Range(x+1, math.MaxUint64) {
+			if count++; count == n {
+				return p, nil
+			}
+		}
+		return 0, ErrRange
+	}
+
+	// The nth prime is at or below x, and it is the (count-n)th one
+	// going down.  Sieve windows below x until it's in one.
+	back := count - n
+	for width := uint64(1 << 16); ; width *= 2 {
+		lo := x - min(width, x)
+		found := []uint64{}
+		for p := range Range(lo+1, x) {
+			found = append(found, p)
+		}
+		if uint64(len(found)) > back {
+			return found[uint64(len(found))-1-back], nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package primes
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPrimePi(t *testing.T) {
+	type Pair struct {
+		input, expected uint64
+	}
+
+	pairs := []Pair{{0, 0}, {1, 0}, {2, 1}, {10, 4}, {100, 25},
+		{1e6, 78498}, {1e9, 50847534}, {1e10, 455052511},
+		{1e11, 4118054813}}
+	for _, p := range pairs {
+		result := PrimePi(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	// Against a sieve, which is where the table stops and Lehmer's
+	// formula starts
+	s := NewSieve(2e6)
+	counts := make([]uint64, s.Limit()+1)
+	for n := uint64(1); n <= s.Limit(); n++ {
+		counts[n] = counts[n-1]
+		if s.IsPrime(n) {
+			counts[n]++
+		}
+	}
+	for i := 0; i < 200; i++ {
+		x := uint64(rand.Int63n(int64(s.Limit())))
+		if result := PrimePi(x); counts[x] != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				x, counts[x], result)
+		}
+	}
+}
+
+func TestLehmerCount(t *testing.T) {
+	// Force small tables, so Lehmer's formula recurses on sums it would
+	// normally look up
+	l := newLehmer(1e6)
+	l.piTable = newPiTable(1100, 1100)
+	for _, x := range []uint64{1101, 5000, 65535, 1e6} {
+		expected := uint64(0)
+		for range NewSieve(x).Primes() {
+			expected++
+		}
+		if result := l.count(x); expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				x, expected, result)
+		}
+	}
+}
+
+func TestNthPrime(t *testing.T) {
+	type Pair struct {
+		input, expected uint64
+	}
+
+	pairs := []Pair{{1, 2}, {2, 3}, {1000, 7919}, {1e6, 15485863},
+		{1e7, 179424673}, {1e9, 22801763489}}
+	for _, p := range pairs {
+		result, err := NthPrime(p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	if _, err := NthPrime(0); err != ErrRange {
+		t.Fatalf("Expected ErrRange, got %v", err)
+	}
+}
+
+func TestIroot(t *testing.T) {
+	type Args struct {
+		n uint64
+		k int
+	}
+
+	type Pair struct {
+		input    Args
+		expected uint64
+	}
+
+	pairs := []Pair{{Args{0, 3}, 0}, {Args{26, 3}, 2}, {Args{27, 3}, 3},
+		{Args{1<<64 - 1, 2}, 1<<32 - 1}, {Args{1<<64 - 1, 4}, 1<<16 - 1},
+		{Args{1e18, 3}, 1e6}, {Args{1e18 - 1, 3}, 1e6 - 1}}
+	for _, p := range pairs {
+		if result := iroot(p.input.n, p.input.k); p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package primes
+
+import (
+	"iter"
+	"math/big"
+	"math/bits"
+)
+
+// LucasLehmer reports whether the Mersenne number 2**p - 1 is prime.  p can
+// be far past 64, since the test works in math/big, but it takes about p
+// squarings of p-bit numbers.
+func LucasLehmer(p uint64) bool {
+	if p == 2 {
+		return true
+	}
+	// 2**p - 1 can only be prime if p is
+	if !IsPrime(p) {
+		return false
+	}
+
+	// s starts at 4 and goes to s**2 - 2 mod m, p-2 times.  2**p is 1 mod m,
+	// so the high bits fold back onto the low bits instead of dividing.
+	one := big.NewInt(1)
+	m := new(big.Int).Lsh(one, uint(p))
+	m.Sub(m, one)
+	s := big.NewInt(4)
+	hi := new(big.Int)
+	for i := uint64(2); i < p; i++ {
+		s.Mul(s, s)
+		s.Sub(s, big.NewInt(2))
+		for s.Cmp(m) > 0 {
+			hi.Rsh(s, uint(p))
+			s.And(s, m)
+			s.Add(s, hi)
+		}
+	}
+	return s.Sign() == 0 || s.Cmp(m) == 0
+}
+
+// IsMersennePrime reports whether n is a prime of the form 2**p - 1.
+func IsMersennePrime(n uint64) bool {
+	p := bits.Len64(n)
+	return n == 1<<p-1 && LucasLehmer(uint64(p))
+}
+
+// MersenneExponents iterates over the primes p from lo to hi for which
+// 2**p - 1 is prime too.
+func MersenneExponents(lo, hi uint64) iter.Seq[uint64] {
+	return filter(lo, hi, LucasLehmer)
+}
+
+// MersennePrimes iterates over the Mersenne primes from lo to hi, which
+// must fit in a uint64.
+func MersennePrimes(lo, hi uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		for p := range MersenneExponents(2, 64) {
+			m := uint64(1)<<p - 1
+			if m > hi {
+				return
+			}
+			if m >= lo && !yield(m) {
+				return
+			}
+		}
+	}
+}
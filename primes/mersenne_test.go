@@ -0,0 +1,42 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLucasLehmer(t *testing.T) {
+	type Pair struct {
+		input    uint64
+		expected bool
+	}
+
+	pairs := []Pair{{0, false}, {1, false}, {2, true}, {3, true},
+		{4, false}, {11, false}, {31, true}, {67, false}, {521, true},
+		{2203, true}, {2207, false}}
+	for _, p := range pairs {
+		result := LucasLehmer(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestIsMersennePrime(t *testing.T) {
+	type Pair struct {
+		input    uint64
+		expected bool
+	}
+
+	pairs := []Pair{{0, false}, {1, false}, {3, true}, {7, true},
+		{15, false}, {2047, false}, {2305843009213693951, true},
+		{math.MaxUint64, false}}
+	for _, p := range pairs {
+		result := IsMersennePrime(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package primes
+
+import "os"
+
+// mapFile reads the whole file at path, where memory mapping isn't
+// available.
+func mapFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// unmapFile does nothing, since mapFile's data is an ordinary slice.
+func unmapFile(data []byte) {}
@@ -0,0 +1,34 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package primes
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path into memory read-only.
+func mapFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return []byte{}, nil
+	}
+	return syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ,
+		syscall.MAP_SHARED)
+}
+
+// unmapFile releases data from mapFile once it's no longer wanted.
+func unmapFile(data []byte) {
+	// An empty file was never mapped
+	if len(data) > 0 {
+		syscall.Munmap(data)
+	}
+}
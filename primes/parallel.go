@@ -0,0 +1,134 @@
+package primes
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// segment is one piece of a ParallelRange, sieved by whichever worker picks
+// it up.
+type segment struct {
+	lo, odds  uint64
+	base      []uint64
+	composite []uint64
+	// done is closed once composite is marked
+	done chan struct{}
+}
+
+// ParallelRange iterates over the primes from lo to hi like Range, but
+// sieves the segments on workers goroutines, up to 2 segments per worker
+// ahead of the caller.  The primes still come in increasing order.  workers
+// of 0 or less means one per CPU.
+func ParallelRange(lo, hi uint64, workers int) iter.Seq[uint64] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return func(yield func(uint64) bool) {
+		if lo <= 2 && 2 <= hi && !yield(2) {
+			return
+		}
+		segLo := max(lo, 3) | 1
+		if segLo < lo || segLo > hi {
+			return
+		}
+
+		cached := Cached(hi)
+		jobs := make(chan *segment)
+		ordered := make(chan *segment, 2*workers)
+		quit := make(chan struct{})
+		defer close(quit)
+
+		for i := 0; i < workers; i++ {
+			go func() {
+				for seg := range jobs {
+					markSegment(seg.composite, seg.lo, seg.odds, cached,
+						seg.base)
+					close(seg.done)
+				}
+			}()
+		}
+
+		// Growing the base primes makes a new slice, so each segment can
+		// hold on to the one it was given
+		go func() {
+			defer close(jobs)
+			defer close(ordered)
+			base := &basePrimes{}
+			for {
+				odds := min((hi-segLo)/2+1, SEGMENT_ODDS)
+				segHi := segLo + 2*(odds-1)
+				seg := &segment{lo: segLo, odds: odds,
+					composite: make([]uint64, (odds+63)/64),
+					done:      make(chan struct{})}
+				if needsBase(segHi, odds, cached) {
+					seg.base = base.upTo(isqrt(segHi))
+				}
+				select {
+				case ordered <- seg:
+				case <-quit:
+					return
+				}
+				select {
+				case jobs <- seg:
+				case <-quit:
+					return
+				}
+				if hi-segHi < 2 {
+					return
+				}
+				segLo = segHi + 2
+			}
+		}()
+
+		for seg := range ordered {
+			<-seg.done
+			for j := uint64(0); j < seg.odds; j++ {
+				if seg.composite[j/64]&(1<<(j%64)) == 0 &&
+					!yield(seg.lo+2*j) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// NewParallelSieve sieves every number up to and including limit like
+// NewSieve, splitting the work into segments across workers goroutines.
+// workers of 0 or less means one per CPU.
+func NewParallelSieve(limit uint64, workers int) *Sieve {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	odds := limit/2 + limit%2
+	s := &Sieve{limit, make([]uint64, (odds+63)/64)}
+	base := NewSieve(isqrt(limit))
+	basePrimes := []uint64{}
+	for p := range base.Primes() {
+		if p != 2 {
+			basePrimes = append(basePrimes, p)
+		}
+	}
+
+	// Segments start on word boundaries, so no two workers write to the
+	// same word
+	starts := make(chan uint64)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range starts {
+				n := min(odds-i, SEGMENT_ODDS)
+				sieveSegment(s.composite[i/64:(i+n+63)/64], 2*i+1, n,
+					basePrimes)
+			}
+		}()
+	}
+	for i := uint64(0); i < odds; i += SEGMENT_ODDS {
+		starts <- i
+	}
+	close(starts)
+	wg.Wait()
+	return s
+}
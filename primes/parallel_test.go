@@ -0,0 +1,57 @@
+package primes
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParallelRange(t *testing.T) {
+	type Args struct {
+		lo, hi uint64
+	}
+
+	// Against Range, including ranges over several segments and at the
+	// top of uint64
+	args := []Args{{0, 0}, {0, 2}, {2, 3}, {10, 1}, {0, 5e6},
+		{1e12, 1e12 + 3e6}, {math.MaxUint64 - 1e4, math.MaxUint64}}
+	for i := 0; i < 20; i++ {
+		lo := uint64(rand.Int63n(1e9))
+		args = append(args, Args{lo, lo + uint64(rand.Intn(2e6))})
+	}
+	for _, a := range args {
+		for _, workers := range []int{0, 1, 3} {
+			expected := slices.Collect(Range(a.lo, a.hi))
+			result := slices.Collect(ParallelRange(a.lo, a.hi, workers))
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("Input: %#v, %v workers\nExpected: %v primes\n"+
+					"     Got: %v primes\n", a, workers, len(expected),
+					len(result))
+			}
+		}
+	}
+
+	// Stopping early, partway into the queued segments
+	count := 0
+	for range ParallelRange(0, math.MaxUint64, 4) {
+		if count++; count == 1e6 {
+			break
+		}
+	}
+}
+
+func TestNewParallelSieve(t *testing.T) {
+	for _, limit := range []uint64{0, 1, 2, 9, 64, 129, SEGMENT_ODDS * 2,
+		SEGMENT_ODDS*2 + 1, 3e6 + 7} {
+		expected := NewSieve(limit)
+		for _, workers := range []int{0, 1, 5} {
+			result := NewParallelSieve(limit, workers)
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("Input: %v, %v workers\nSieves differ", limit,
+					workers)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package primes finds and tests prime numbers.
+//
+// IsPrime answers for any single uint64 with Miller-Rabin.  Sieve finds
+// every prime up to a limit that fits in memory, and Range streams the
+// primes in any range, one segment at a time, for when it doesn't.
+// ParallelRange and NewParallelSieve share the segments out across
+// goroutines, and setting PRIMES_CACHE to a directory keeps a sieve there
+// for later runs to map into memory (see Cached).
+//
+// Factor splits any uint64 into primes, and the arithmetic functions build
+// on it.  The classifiers pick out emirps, twin, circular, truncatable,
+// Sophie Germain and Mersenne primes, each as an iterator over a range.
+package primes
+
+import (
+	"math"
+	"math/bits"
+)
+
+// isqrt returns the largest r with r*r <= n.
+func isqrt(n uint64) uint64 {
+	r := uint64(math.Sqrt(float64(n)))
+	// The float can be off by one either way for large n
+	for r > 0 && (r > math.MaxUint32 || r*r > n) {
+		r--
+	}
+	for r+1 <= math.MaxUint32 && (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// smallPrimes are tried as divisors before Miller-Rabin.  It catches most
+// composites faster, and it means the bases below never divide n.
+var smallPrimes = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// millerRabinBases are enough to make Miller-Rabin deterministic for every
+// uint64 (Jim Sinclair's set).
+var millerRabinBases = []uint64{
+	2, 325, 9375, 28178, 450775, 9780504, 1795265022}
+
+// IsPrime reports whether n is prime.  It uses Miller-Rabin with a set of
+// bases that has no false positives below 2**64, so the answer is exact.
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range smallPrimes {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	// n-1 = d * 2**s with d odd
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= s
+
+	for _, a := range millerRabinBases {
+		a %= n
+		if a == 0 {
+			continue
+		}
+		x := PowMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		isWitness := true
+		for r := 1; r < s; r++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				isWitness = false
+				break
+			}
+		}
+		// a proves n is composite
+		if isWitness {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+// slowIsPrime is trial division by everything up to the square root.
+func slowIsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for i := uint64(2); i*i <= n; i++ {
+		if n%i == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIsPrime(t *testing.T) {
+	type Pair struct {
+		input    uint64
+		expected bool
+	}
+
+	pairs := []Pair{{0, false}, {1, false}, {2, true}, {3, true},
+		{4, false}, {7, true}, {37, true}, {41, true}, {561, false},
+		// Strong pseudoprime to bases 2, 3, 5, 7, 11, 13, 17, 19, 23
+		{3825123056546413051, false},
+		// Largest primes below 2**32 and 2**64
+		{4294967291, true}, {18446744073709551557, true},
+		{math.MaxUint64, false},
+		// 2**61 - 1 is a Mersenne prime, and its square is not
+		{2305843009213693951, true}, {4294967291 * 4294967279, false},
+	}
+	for _, p := range pairs {
+		result := IsPrime(p.input)
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	for n := uint64(0); n < 10000; n++ {
+		if expected, result := slowIsPrime(n), IsPrime(n); expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				n, expected, result)
+		}
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	type Pair struct {
+		input, expected uint64
+	}
+
+	pairs := []Pair{{0, 0}, {1, 1}, {3, 1}, {4, 2}, {99, 9},
+		{math.MaxUint64, math.MaxUint32},
+		{(1<<32 - 1) * (1<<32 - 1), 1<<32 - 1},
+		{(1<<32-1)*(1<<32-1) - 1, 1<<32 - 2}}
+	for _, p := range pairs {
+		if result := isqrt(p.input); p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package primes
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// u128 is an unsigned 128-bit integer, enough for any sum of primes below
+// 2**64.
+type u128 struct {
+	hi, lo uint64
+}
+
+func (a u128) add(b u128) u128 {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, carry)
+	return u128{hi, lo}
+}
+
+func (a u128) sub(b u128) u128 {
+	lo, borrow := bits.Sub64(a.lo, b.lo, 0)
+	hi, _ := bits.Sub64(a.hi, b.hi, borrow)
+	return u128{hi, lo}
+}
+
+// mul64 returns a * b.  Anything past 128 bits is lost.
+func (a u128) mul64(b uint64) u128 {
+	hi, lo := bits.Mul64(a.lo, b)
+	return u128{hi + a.hi*b, lo}
+}
+
+// big converts a to a big.Int.
+func (a u128) big() *big.Int {
+	rv := new(big.Int).SetUint64(a.hi)
+	rv.Lsh(rv, 64)
+	return rv.Add(rv, new(big.Int).SetUint64(a.lo))
+}
+
+// triangle returns 2 + 3 + ... + v, the sum PrimeSum starts from.
+func triangle(v uint64) u128 {
+	// v(v+1)/2, halving whichever of the two is even first so it fits.
+	// (v+1)/2 is written v/2+1 so it can't wrap around when v is odd.
+	a, b := v/2, v+1
+	if v%2 == 1 {
+		a, b = v, v/2+1
+	}
+	hi, lo := bits.Mul64(a, b)
+	return u128{hi, lo}.sub(u128{0, 1})
+}
+
+// PrimeSum returns the sum of the primes up to x, in about O(x**3/4) time
+// and O(sqrt(x)) space.
+//
+// This is Lucy Hedgehog's method.  Only the sums up to x/k for each k are
+// ever needed, and there are just 2 sqrt(x) different values of x/k.  Every
+// sum starts as the sum of all 2..v, and for each prime p in turn, the
+// numbers whose smallest prime factor is p are taken out.  Those are p
+// times the numbers up to v/p with no prime factor below p, whose sum is
+// already known.
+func PrimeSum(x uint64) *big.Int {
+	if x < 2 {
+		return new(big.Int)
+	}
+	r := isqrt(x)
+
+	// small[v] is the sum for v <= r, and large[k] is the sum for x/k
+	small := make([]u128, r+1)
+	large := make([]u128, r+1)
+	for v := uint64(1); v <= r; v++ {
+		small[v] = triangle(v)
+		large[v] = triangle(x / v)
+	}
+	sumAt := func(v uint64) u128 {
+		if v <= r {
+			return small[v]
+		}
+		return large[x/v]
+	}
+
+	for p := uint64(2); p <= r; p++ {
+		// p was taken out with its smallest factor, so it is not prime
+		if small[p] == small[p-1] {
+			continue
+		}
+		below := small[p-1]
+		p2 := p * p
+
+		// Largest values first, so the ones being read are still from
+		// the last prime
+		for k := uint64(1); k <= r && x/k >= p2; k++ {
+			large[k] = large[k].sub(sumAt(x / k / p).sub(below).mul64(p))
+		}
+		for v := r; v >= p2; v-- {
+			small[v] = small[v].sub(small[v/p].sub(below).mul64(p))
+		}
+	}
+	return large[1].big()
+}
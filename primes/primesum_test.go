@@ -0,0 +1,57 @@
+package primes
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestPrimeSum(t *testing.T) {
+	type Pair struct {
+		input    uint64
+		expected string
+	}
+
+	pairs := []Pair{{0, "0"}, {1, "0"}, {2, "2"}, {3, "5"}, {10, "17"},
+		{100, "1060"}, {1e6, "37550402023"},
+		{1e9, "24739512092254535"},
+		// Past 2**64
+		{1e11, "201467077743744681014"}}
+	for _, p := range pairs {
+		result := PrimeSum(p.input).String()
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+
+	// Against a sieve
+	for i := 0; i < 100; i++ {
+		x := uint64(rand.Intn(1e5))
+		expected := new(big.Int)
+		for p := range NewSieve(x).Primes() {
+			expected.Add(expected, new(big.Int).SetUint64(p))
+		}
+		if result := PrimeSum(x); expected.Cmp(result) != 0 {
+			t.Fatalf("Input: %#v\nExpected: %v\n     Got: %v\n",
+				x, expected, result)
+		}
+	}
+}
+
+func TestU128(t *testing.T) {
+	a := u128{0, 1<<64 - 1}.add(u128{0, 1})
+	if a != (u128{1, 0}) {
+		t.Fatalf("Expected a carry, got %#v", a)
+	}
+	if b := a.sub(u128{0, 1}); b != (u128{0, 1<<64 - 1}) {
+		t.Fatalf("Expected a borrow, got %#v", b)
+	}
+	if c := a.mul64(3).big().String(); c != "55340232221128654848" {
+		t.Fatalf("Expected 3 * 2**64, got %v", c)
+	}
+	if d := triangle(1<<64 - 1).big().String(); d !=
+		"170141183460469231722463931679029329919" {
+		t.Fatalf("Expected (2**64-1) 2**63 - 1, got %v", d)
+	}
+}
@@ -0,0 +1,138 @@
+package primes
+
+import (
+	"iter"
+	"math"
+)
+
+// SEGMENT_ODDS is how many odd numbers Range sieves at a time.  One bit each
+// makes it 32 KiB, which stays in a typical L1 or L2 cache.
+const SEGMENT_ODDS = 1 << 18
+
+// MILLER_RABIN_COST is roughly how many base primes Range could cross off
+// with in the time it takes IsPrime to test one number.
+const MILLER_RABIN_COST = 64
+
+// basePrimes are the odd primes a segmented sieve crosses off with.  They
+// are only sieved as far as they are needed, and then some, so ranges that
+// go on forever don't sieve up to 2**32 before starting.
+type basePrimes struct {
+	limit  uint64
+	primes []uint64
+}
+
+// upTo makes sure every odd prime up to n is there, and returns them all.
+func (b *basePrimes) upTo(n uint64) []uint64 {
+	if n > b.limit {
+		b.limit = min(max(n, 2*b.limit), math.MaxUint32)
+		// A new slice each time, since earlier ones may still be in use
+		primes := []uint64{}
+		for p := range NewSieve(b.limit).Primes() {
+			if p != 2 {
+				primes = append(primes, p)
+			}
+		}
+		b.primes = primes
+	}
+	return b.primes
+}
+
+// Range iterates over the primes from lo to hi, including both, in
+// increasing order.  It sieves a segment at a time with the primes up to the
+// square root of the segment, so memory stays small no matter how big hi is.
+// Pass math.MaxUint64 as hi to keep going until the caller stops.
+func Range(lo, hi uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		if lo <= 2 && 2 <= hi && !yield(2) {
+			return
+		}
+		// Segments start odd and only hold odd numbers
+		segLo := max(lo, 3) | 1
+		if segLo < lo || segLo > hi {
+			return
+		}
+
+		cached := Cached(hi)
+		base := &basePrimes{}
+		composite := make([]uint64, SEGMENT_ODDS/64)
+		for {
+			odds := min((hi-segLo)/2+1, SEGMENT_ODDS)
+			segHi := segLo + 2*(odds-1)
+
+			var b []uint64
+			if needsBase(segHi, odds, cached) {
+				b = base.upTo(isqrt(segHi))
+			}
+			markSegment(composite, segLo, odds, cached, b)
+
+			for j := uint64(0); j < odds; j++ {
+				if composite[j/64]&(1<<(j%64)) == 0 &&
+					!yield(segLo+2*j) {
+					return
+				}
+			}
+
+			// Careful not to wrap around at the top of uint64
+			if hi-segHi < 2 {
+				return
+			}
+			segLo = segHi + 2
+		}
+	}
+}
+
+// needsBase reports whether markSegment will sieve the segment ending at
+// segHi, and so needs the base primes up to its square root.
+func needsBase(segHi, odds uint64, cached *Sieve) bool {
+	// Crossing off takes a step per base prime whether or not it hits
+	// anything, so for a short segment high up, testing each number is a
+	// lot cheaper
+	return !cached.covers(segHi) && odds >= isqrt(segHi)/MILLER_RABIN_COST
+}
+
+// markSegment sets the bits in composite for the composites among the odds
+// odd numbers from segLo.  It copies them from cached if that goes far
+// enough, sieves with base if needsBase says to, and otherwise tests each
+// number.
+func markSegment(composite []uint64, segLo, odds uint64, cached *Sieve,
+	base []uint64) {
+	segHi := segLo + 2*(odds-1)
+	switch {
+	case cached.covers(segHi):
+		cached.copyOdds(composite, segLo, odds)
+	case needsBase(segHi, odds, cached):
+		clear(composite)
+		sieveSegment(composite, segLo, odds, base)
+	default:
+		clear(composite)
+		for j := uint64(0); j < odds; j++ {
+			if !IsPrime(segLo + 2*j) {
+				composite[j/64] |= 1 << (j % 64)
+			}
+		}
+	}
+}
+
+// sieveSegment crosses off the composites among the odds odd numbers from
+// segLo, using base, the odd primes up to the square root of the last one.
+func sieveSegment(composite []uint64, segLo, odds uint64, base []uint64) {
+	segHi := segLo + 2*(odds-1)
+	for _, p := range base {
+		if p*p > segHi {
+			break
+		}
+		// Start from the first odd multiple of p in the segment, but
+		// never p itself.  Working with offsets from segLo can't
+		// overflow.
+		off := (p - segLo%p) % p
+		if off%2 == 1 {
+			off += p
+		}
+		if p*p > segLo {
+			off = max(off, p*p-segLo)
+		}
+		for j := off / 2; j < odds; j += p {
+			composite[j/64] |= 1 << (j % 64)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package primes
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	type Args struct {
+		lo, hi uint64
+	}
+
+	type Pair struct {
+		input    Args
+		expected string
+	}
+
+	pairs := []Pair{
+		{Args{0, 30}, "[2 3 5 7 11 13 17 19 23 29]"},
+		{Args{3, 3}, "[3]"},
+		{Args{4, 4}, "[]"},
+		{Args{24, 29}, "[29]"},
+		{Args{30, 2}, "[]"},
+		{Args{1e12, 1e12 + 100}, "[1000000000039 1000000000061 " +
+			"1000000000063 1000000000091]"},
+		{Args{math.MaxUint64 - 100, math.MaxUint64},
+			"[18446744073709551521 18446744073709551533 " +
+				"18446744073709551557]"},
+	}
+	for _, p := range pairs {
+		result := fmt.Sprint(slices.Collect(Range(p.input.lo, p.input.hi)))
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestRangeMatchesSieve(t *testing.T) {
+	// Several segments' worth, starting at odd and even places
+	s := NewSieve(3 * 2 * SEGMENT_ODDS)
+	for _, lo := range []uint64{0, 1, 1000, 1001, 2*SEGMENT_ODDS - 1} {
+		var expected []uint64
+		for p := range s.Primes() {
+			if p >= lo {
+				expected = append(expected, p)
+			}
+		}
+		result := slices.Collect(Range(lo, s.Limit()))
+		if !slices.Equal(expected, result) {
+			t.Fatalf("Range(%v, %v) has %v primes, expected %v",
+				lo, s.Limit(), len(result), len(expected))
+		}
+	}
+
+	// Stopping early, with no end in sight
+	count := 0
+	for p := range Range(0, math.MaxUint64) {
+		if count++; count == 1000 {
+			if p != 7919 {
+				t.Fatalf("Expected the 1000th prime to be 7919, got %v",
+					p)
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package primes
+
+import "iter"
+
+// Sieve is a Sieve of Eratosthenes holding whether every number up to its
+// limit is prime.  Only odd numbers are stored, one bit each, so a limit of
+// n takes n/16 bytes.
+type Sieve struct {
+	limit uint64
+	// Bit i is set when 2i+1 is composite
+	composite []uint64
+}
+
+// NewSieve sieves every number up to and including limit.
+func NewSieve(limit uint64) *Sieve {
+	odds := limit/2 + limit%2
+	s := &Sieve{limit, make([]uint64, (odds+63)/64)}
+
+	for i := uint64(1); ; i++ {
+		p := 2*i + 1
+		if p*p > limit {
+			break
+		}
+		if s.isComposite(i) {
+			continue
+		}
+		// Smaller multiples of p were crossed off by smaller primes,
+		// and even ones aren't stored, so step 2p from p*p
+		for j := p * p / 2; j < odds; j += p {
+			s.composite[j/64] |= 1 << (j % 64)
+		}
+	}
+	return s
+}
+
+// isComposite reports whether the bit for 2i+1 is set.
+func (s *Sieve) isComposite(i uint64) bool {
+	return s.composite[i/64]&(1<<(i%64)) != 0
+}
+
+// Limit returns the largest number the sieve holds.
+func (s *Sieve) Limit() uint64 {
+	return s.limit
+}
+
+// IsPrime reports whether n is prime.  Past the limit it falls back to the
+// IsPrime function.
+func (s *Sieve) IsPrime(n uint64) bool {
+	switch {
+	case n > s.limit:
+		return IsPrime(n)
+	case n < 3:
+		return n == 2
+	case n%2 == 0:
+		return false
+	}
+	return !s.isComposite(n / 2)
+}
+
+// covers reports whether n is within the sieve.  A nil sieve covers nothing.
+func (s *Sieve) covers(n uint64) bool {
+	return s != nil && n <= s.limit
+}
+
+// copyOdds copies the bits for the odds odd numbers from odd n into dst, so
+// bit j of dst is for n+2j.  Bits past odds in the last word are left over
+// from the sieve.
+func (s *Sieve) copyOdds(dst []uint64, n, odds uint64) {
+	w, shift := n/2/64, n/2%64
+	for k := uint64(0); k < (odds+63)/64; k++ {
+		word := s.composite[w+k] >> shift
+		if shift != 0 && w+k+1 < uint64(len(s.composite)) {
+			word |= s.composite[w+k+1] << (64 - shift)
+		}
+		dst[k] = word
+	}
+}
+
+// Primes iterates over the primes in the sieve in increasing order.
+func (s *Sieve) Primes() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		if s.limit >= 2 && !yield(2) {
+			return
+		}
+		for n := uint64(3); n <= s.limit; n += 2 {
+			if !s.isComposite(n/2) && !yield(n) {
+				return
+			}
+		}
+	}
+}
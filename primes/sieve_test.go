@@ -0,0 +1,50 @@
+package primes
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSieve(t *testing.T) {
+	type Pair struct {
+		input    uint64
+		expected string
+	}
+
+	pairs := []Pair{
+		{0, "[]"},
+		{1, "[]"},
+		{2, "[2]"},
+		{3, "[2 3]"},
+		{30, "[2 3 5 7 11 13 17 19 23 29]"},
+		{31, "[2 3 5 7 11 13 17 19 23 29 31]"},
+	}
+	for _, p := range pairs {
+		result := fmt.Sprint(slices.Collect(NewSieve(p.input).Primes()))
+		if p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestSieveIsPrime(t *testing.T) {
+	s := NewSieve(10000)
+	for n := uint64(0); n < 10100; n++ {
+		// Past the limit falls back to Miller-Rabin
+		if expected, result := slowIsPrime(n), s.IsPrime(n); expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				n, expected, result)
+		}
+	}
+
+	// pi(10**6) = 78498
+	count := 0
+	for range NewSieve(1e6).Primes() {
+		count++
+	}
+	if count != 78498 {
+		t.Fatalf("Expected 78498 primes below 10**6, got %v", count)
+	}
+}
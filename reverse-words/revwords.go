@@ -11,6 +11,8 @@ import (
 // Imports for the specific problem
 import (
 	"strings"
+
+	"github.com/carbonizer/codeeval-go/sliceutil"
 )
 
 //
@@ -80,21 +82,11 @@ func main() {
 	inputToFuncToStdout(ReverseWords, INPUT_FILEARG)
 }
 
-// revStrings returns a slice of strings in the reverse order.
-func revStrings(strs []string) []string {
-	strsLen := len(strs)
-	revs := make([]string, strsLen)
-	for i, str := range strs {
-		revs[strsLen-1-i] = str
-	}
-	return revs
-}
-
 func ReverseWords(stdin []byte) (interface{}, error) {
 	lines := strings.Split(string(stdin), "\n")
 	revLines := make([]string, len(lines))
 	for i, line := range lines {
-		revLines[i] = strings.Join(revStrings(
+		revLines[i] = strings.Join(sliceutil.Reverse(
 			strings.Split(line, " ")), " ")
 	}
 	return strings.Join(revLines, "\n"), nil
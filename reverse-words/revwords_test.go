@@ -17,7 +17,7 @@ func TestReverseWords(t *testing.T) {
 			t.Fatal(err)
 		}
 		if p.expected != result {
-			fmt.Println("Input: %#v", p.input)
+			fmt.Printf("Input: %#v\n", p.input)
 			t.Fatalf("Expected %#v, got %#v", p.expected, result)
 		}
 	}
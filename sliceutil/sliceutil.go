@@ -0,0 +1,176 @@
+// Package sliceutil has the slice conversions nearly every solution needs,
+// like turning a line of space-separated numbers into a slice of ints and
+// back again.
+package sliceutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Signed is any signed integer type.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is any unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is any integer type.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// Concat concatenates each slice of a slice of slices into a single slice.
+func Concat[T any](outer [][]T) []T {
+	size := 0
+	for _, inner := range outer {
+		size += len(inner)
+	}
+	outs := make([]T, 0, size)
+	for _, inner := range outer {
+		outs = append(outs, inner...)
+	}
+	return outs
+}
+
+// Reverse returns a copy of a slice with the elements in the opposite order.
+func Reverse[T any](s []T) []T {
+	sLen := len(s)
+	revs := make([]T, sLen)
+	for i, x := range s {
+		revs[sLen-1-i] = x
+	}
+	return revs
+}
+
+// ParseError records which element of ParseAll's input couldn't be parsed.
+type ParseError struct {
+	Index int
+	Str   string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("element %d (%#v): %v", e.Index, e.Str, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is every element ParseAll couldn't parse, in order.
+type ParseErrors []*ParseError
+
+func (es ParseErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return "cannot parse " + strings.Join(msgs, "; ")
+}
+
+// kind sorts T into the strconv function that can parse or format it.  It
+// goes by arithmetic rather than a type switch so types declared like
+// `type int_ int` are sorted by their underlying type.
+func kind[T Number]() byte {
+	var zero, one T = 0, 1
+	switch {
+	case one/2 != zero:
+		return 'f'
+	case zero-1 < zero:
+		return 'i'
+	}
+	return 'u'
+}
+
+// bitSizeOf returns how many bits T takes up.
+func bitSizeOf[T Number]() int {
+	var zero T
+	return int(unsafe.Sizeof(zero)) * 8
+}
+
+// ParseAll converts a slice of strings to a slice of numbers.  base is used
+// for integers (0 means to go by the prefix, like "0x") and ignored for
+// floats.  bitSize limits the range like it does for strconv; 0 means to use
+// the size of T.
+//
+// Rather than stopping at the first bad element, every element is tried, and
+// the error is a ParseErrors listing every one that failed.  The returned
+// slice is still complete, with zeros where parsing failed.
+func ParseAll[T Number](strs []string, base, bitSize int) ([]T, error) {
+	if bitSize == 0 {
+		bitSize = bitSizeOf[T]()
+	}
+
+	nums := make([]T, len(strs))
+	var errs ParseErrors
+	for i, str := range strs {
+		var err error
+		switch kind[T]() {
+		case 'f':
+			var f float64
+			f, err = strconv.ParseFloat(str, bitSize)
+			nums[i] = T(f)
+		case 'i':
+			var n int64
+			n, err = strconv.ParseInt(str, base, bitSize)
+			nums[i] = T(n)
+		default:
+			var n uint64
+			n, err = strconv.ParseUint(str, base, bitSize)
+			nums[i] = T(n)
+		}
+		if err != nil {
+			errs = append(errs, &ParseError{i, str, err})
+		}
+	}
+	if errs != nil {
+		return nums, errs
+	}
+	return nums, nil
+}
+
+// Fields is ParseAll for a line of space-separated base 10 numbers, which is
+// how most challenges format their input.
+func Fields[T Number](line string) ([]T, error) {
+	return ParseAll[T](strings.Fields(line), 10, 0)
+}
+
+// FormatAll converts a slice of numbers to a slice of strings.  base is used
+// for integers and ignored for floats, which use the shortest
+// representation that parses back to the same value.
+func FormatAll[T Number](nums []T, base int) []string {
+	strs := make([]string, len(nums))
+	for i, num := range nums {
+		switch kind[T]() {
+		case 'f':
+			strs[i] = strconv.FormatFloat(
+				float64(num), 'g', -1, bitSizeOf[T]())
+		case 'i':
+			strs[i] = strconv.FormatInt(int64(num), base)
+		default:
+			strs[i] = strconv.FormatUint(uint64(num), base)
+		}
+	}
+	return strs
+}
+
+// Join formats nums in base 10 and joins them with sep.
+func Join[T Number](nums []T, sep string) string {
+	return strings.Join(FormatAll(nums, 10), sep)
+}
@@ -0,0 +1,118 @@
+package sliceutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	result := Concat([][]uint{{0, 1}, {}, {2}})
+	expected := []uint{0, 1, 2}
+	if fmt.Sprintf("%#v", expected) != fmt.Sprintf("%#v", result) {
+		t.Fatalf("Expected %#v, got %#v", expected, result)
+	}
+
+	strs := Concat([][]string{{"a"}, {"b", "c"}})
+	if fmt.Sprint(strs) != "[a b c]" {
+		t.Fatalf("Expected %v, got %v", "[a b c]", strs)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	input := []string{"Hello", "World"}
+	result := Reverse(input)
+	if fmt.Sprint(result) != "[World Hello]" {
+		t.Fatalf("Expected %v, got %v", "[World Hello]", result)
+	}
+	// The input is left alone
+	if fmt.Sprint(input) != "[Hello World]" {
+		t.Fatalf("Input was modified: %v", input)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	ints, err := ParseAll[int]([]string{"36", "-47", "0"}, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ints) != "[36 -47 0]" {
+		t.Fatalf("Expected %v, got %v", "[36 -47 0]", ints)
+	}
+
+	hex, err := ParseAll[uint16]([]string{"ff", "0"}, 16, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(hex) != "[255 0]" {
+		t.Fatalf("Expected %v, got %v", "[255 0]", hex)
+	}
+
+	floats, err := ParseAll[float64]([]string{"1.5", "-2e3"}, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(floats) != "[1.5 -2000]" {
+		t.Fatalf("Expected %v, got %v", "[1.5 -2000]", floats)
+	}
+
+	// Types declared in a solution work the same as their underlying type
+	type int_ int
+	custom, err := ParseAll[int_]([]string{"-3"}, 10, 0)
+	if err != nil || custom[0] != -3 {
+		t.Fatalf("Expected [-3], got %v, %v", custom, err)
+	}
+}
+
+func TestParseAllErrors(t *testing.T) {
+	// 300 is too big for the bit size, and x isn't a number at all
+	nums, err := ParseAll[int]([]string{"1", "300", "2", "x"}, 10, 8)
+	var errs ParseErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected ParseErrors, got %#v", err)
+	}
+	if len(errs) != 2 || errs[0].Index != 1 || errs[1].Index != 3 {
+		t.Fatalf("Expected errors at 1 and 3, got %v", err)
+	}
+	if !errors.Is(errs[0], strconv.ErrRange) {
+		t.Fatalf("Expected a range error, got %v", errs[0])
+	}
+	// Everything that could be parsed still was
+	if nums[0] != 1 || nums[2] != 2 {
+		t.Fatalf("Expected 1 and 2 to be parsed, got %v", nums)
+	}
+
+	if _, err := ParseAll[uint]([]string{"-1"}, 10, 0); err == nil {
+		t.Fatal("Expected an error parsing -1 as a uint")
+	}
+}
+
+func TestFields(t *testing.T) {
+	result, err := Fields[int]("48 51  5 61 18")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(result) != "[48 51 5 61 18]" {
+		t.Fatalf("Expected %v, got %v", "[48 51 5 61 18]", result)
+	}
+}
+
+func TestFormatAll(t *testing.T) {
+	type Pair struct {
+		input, expected string
+	}
+
+	pairs := []Pair{
+		{fmt.Sprint(FormatAll([]int{-5, 10}, 10)), "[-5 10]"},
+		{fmt.Sprint(FormatAll([]uint{5, 10}, 2)), "[101 1010]"},
+		{fmt.Sprint(FormatAll([]float32{0.1, 2}, 10)), "[0.1 2]"},
+		{Join([]uint{0, 1, 2}, ""), "012"},
+		{Join([]int{}, " "), ""},
+	}
+	for _, p := range pairs {
+		if p.expected != p.input {
+			t.Fatalf("Expected %#v, got %#v", p.expected, p.input)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// primeSum adds up primes in a uint64, counting every time it wraps around,
+// so the total is exact however big it gets.  math/big is only needed to
+// write out a total past 2**64.
+type primeSum struct {
+	lo, wraps uint64
+}
+
+// add adds p to the sum.
+func (s *primeSum) add(p uint64) {
+	var carry uint64
+	s.lo, carry = bits.Add64(s.lo, p, 0)
+	s.wraps += carry
+}
+
+// String returns the sum in decimal.
+func (s primeSum) String() string {
+	if s.wraps == 0 {
+		return strconv.FormatUint(s.lo, 10)
+	}
+	sum := new(big.Int).SetUint64(s.wraps)
+	sum.Lsh(sum, 64)
+	sum.Add(sum, new(big.Int).SetUint64(s.lo))
+	return sum.String()
+}
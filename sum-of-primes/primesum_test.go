@@ -0,0 +1,31 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrimeSum(t *testing.T) {
+	type Pair struct {
+		input    []uint64
+		expected string
+	}
+
+	pairs := []Pair{
+		{[]uint64{}, "0"},
+		{[]uint64{2, 3, 5}, "10"},
+		{[]uint64{math.MaxUint64, 1}, "18446744073709551616"},
+		{[]uint64{math.MaxUint64, math.MaxUint64, math.MaxUint64},
+			"55340232221128654845"},
+	}
+	for _, p := range pairs {
+		sum := primeSum{}
+		for _, n := range p.input {
+			sum.add(n)
+		}
+		if result := sum.String(); p.expected != result {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
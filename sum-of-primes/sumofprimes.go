@@ -11,6 +11,9 @@ import (
 // Imports for the specific problem
 import (
 	"strconv"
+	"strings"
+
+	"github.com/carbonizer/codeeval-go/primes"
 )
 
 //
@@ -77,41 +80,121 @@ const FAKE_INPUT = "1000"
 
 // Just update the function name and the input type
 func main() {
-	inputToFuncToStdout(SumOfPrimes, INPUT_CONSTANT)
+	inputToFuncToStdout(SumOfPrimes, INPUT_FILEARG)
 }
 
-// isPrime returns true if n is a prime number.
-func isPrime(n int) bool {
-	switch n {
-	case 0, 1:
-		return false
-	default:
-		for i := 2; i < n; i++ {
-			// i is a factor if the remainder is 0
-			if n%i == 0 {
-				return false
-			}
+// parseQuery parses a line of "first N" (or just "N"), "below X" or
+// "range A B", and returns the range of primes to add up and how many of
+// them to take, or 0 for all of them.
+func parseQuery(line string) (lo, hi, count uint64, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 1 {
+		fields = []string{"first", fields[0]}
+	}
+	if len(fields) < 2 {
+		return 0, 0, 0, fmt.Errorf("Cannot parse %#v", line)
+	}
+
+	args := make([]uint64, len(fields)-1)
+	for i, field := range fields[1:] {
+		args[i], err = strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf(
+				"Cannot convert %#v to number", field)
 		}
 	}
-	return true
+
+	switch {
+	case fields[0] == "first" && len(args) == 1:
+		if args[0] == 0 {
+			return 1, 0, 0, nil
+		}
+		// The nth prime is no bigger than the bound, so that's as
+		// far as the sieve needs to go
+		return 0, primes.NthPrimeUpperBound(args[0]), args[0], nil
+	case fields[0] == "below" && len(args) == 1:
+		if args[0] == 0 {
+			// Nothing is below 0, so the range is empty
+			return 1, 0, 0, nil
+		}
+		return 0, args[0] - 1, 0, nil
+	case fields[0] == "range" && len(args) == 2:
+		return args[0], args[1], 0, nil
+	}
+	return 0, 0, 0, fmt.Errorf("Cannot parse %#v", line)
 }
 
-func SumOfPrimes(stdin []byte) (interface{}, error) {
-	n, err := strconv.Atoi(string(stdin))
+// SIEVE_SPAN_MAX is the widest range of primes that are added up one at a
+// time.  Past it, sums come from primes.PrimeSum, which takes about x**3/4
+// steps instead of x.
+const SIEVE_SPAN_MAX = 1e8
+
+// PRIME_SUM_MAX is the largest hi that primes.PrimeSum is asked about.  It
+// keeps 2 sqrt(hi) 128-bit sums, so about 32 MB here, and far more than
+// fits in memory near 2**64.
+const PRIME_SUM_MAX = 1e12
+
+// sumPrimes adds up the first count primes from lo to hi, or all of them
+// if count is 0.
+func sumPrimes(lo, hi, count uint64) (string, error) {
+	if lo > hi {
+		return "0", nil
+	}
 
-	if err != nil {
-		fmt.Println()
-		return "", fmt.Errorf(
-			"Cannot convert %#v to number\n", string(stdin))
+	if hi-lo > SIEVE_SPAN_MAX {
+		errTooBig := fmt.Errorf("Cannot sum primes past %v",
+			uint64(PRIME_SUM_MAX))
+		if count > 0 {
+			// hi is only a bound, so find where the primes end
+			if primes.NthPrimeLowerBound(count) > PRIME_SUM_MAX {
+				return "", errTooBig
+			}
+			nth, err := primes.NthPrime(count)
+			if err != nil {
+				return "", err
+			}
+			hi = nth
+		}
+		if hi > PRIME_SUM_MAX {
+			return "", errTooBig
+		}
+		sum := primes.PrimeSum(hi)
+		if lo > 0 {
+			sum.Sub(sum, primes.PrimeSum(lo-1))
+		}
+		return sum.String(), nil
 	}
 
-	sum := 0
-	for i, numPrimes := 0, 0; numPrimes < n; i++ {
-		if isPrime(i) {
-			sum += i
-			numPrimes++
+	sum, numPrimes := primeSum{}, uint64(0)
+	for p := range primes.ParallelRange(lo, hi, 0) {
+		if count > 0 && numPrimes >= count {
+			break
+		}
+		sum.add(p)
+		numPrimes++
+	}
+	return sum.String(), nil
+}
+
+// SumOfPrimes answers one query per line (see parseQuery), each with the
+// sum of some primes.  The original problem is just "1000", the sum of the
+// first 1000 primes, which is what empty input answers.
+func SumOfPrimes(stdin []byte) (interface{}, error) {
+	text := strings.TrimSpace(string(stdin))
+	if text == "" {
+		text = FAKE_INPUT
+	}
+	lines := strings.Split(text, "\n")
+	outLines := make([]string, len(lines))
+	for i, line := range lines {
+		lo, hi, count, err := parseQuery(line)
+		if err == nil {
+			outLines[i], err = sumPrimes(lo, hi, count)
+		}
+		if err != nil {
+			return strings.Join(outLines[:i], "\n"), err
 		}
 	}
 
-	return sum, nil
+	return strings.Join(outLines, "\n"), nil
 }
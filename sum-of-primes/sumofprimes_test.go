@@ -1,25 +1,49 @@
 package main
 
 import (
-	"fmt"
 	"testing"
 )
 
 func TestSumOfPrimes(t *testing.T) {
 	type Pair struct {
-		input    string
-		expected int
+		input, expected string
 	}
 
-	pairs := []Pair{{"1000", 3682913}}
+	pairs := []Pair{
+		{"1000", "3682913"},
+		// Empty input is the original problem
+		{"", "3682913"},
+		{"first 1000\nfirst 0\nfirst 1\n", "3682913\n0\n2"},
+		{"below 10\nbelow 0\nbelow 3", "17\n0\n2"},
+		{"range 10 20\nrange 20 10\nrange 0 2", "60\n0\n2"},
+		// The sum of the first million primes
+		{"1000000", "7472966967499"},
+		// These are too many to sieve
+		{"first 1000000000", "11138479445180240497"},
+		{"below 100000000000", "201467077743744681014"},
+		{"range 1000000000 100000000000", "201442338231652426479"},
+	}
 	for _, p := range pairs {
 		result, err := SumOfPrimes([]byte(p.input))
 		if err != nil {
 			t.Fatal(err)
 		}
 		if p.expected != result {
-			fmt.Println("Input: %#v", p.input)
-			t.Fatalf("Expected %#v, got %#v", p.expected, result)
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, result)
+		}
+	}
+}
+
+func TestSumOfPrimesErrors(t *testing.T) {
+	for _, input := range []string{"x", "first", "first -1", "last 10",
+		"range 10", "below 1 2",
+		"first 18446744073709551615",
+		// Past PRIME_SUM_MAX, PrimeSum would need too much memory
+		"below 18446744073709551615", "range 1 1000000000001",
+		"first 100000000000"} {
+		if _, err := SumOfPrimes([]byte(input)); err == nil {
+			t.Fatalf("Expected an error for %#v", input)
 		}
 	}
 }
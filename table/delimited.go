@@ -0,0 +1,22 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// Delimited writes each row as a record separated by Comma, quoting cells
+// as CSV does when they need it.  Headers are just the first row and
+// column.
+type Delimited struct {
+	Comma rune
+}
+
+func (d Delimited) Render(w io.Writer, t *Table) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = d.Comma
+	if err := cw.WriteAll(t.Cells); err != nil {
+		return err
+	}
+	return cw.Error()
+}
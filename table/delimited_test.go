@@ -0,0 +1,31 @@
+package table
+
+import (
+	"testing"
+)
+
+func TestDelimited(t *testing.T) {
+	type Pair struct {
+		comma    rune
+		input    *Table
+		expected string
+	}
+
+	pairs := []Pair{
+		{',', grid, "*,1,2\n1,1,2\n10,10,20"},
+		{'\t', grid, "*\t1\t2\n1\t1\t2\n10\t10\t20"},
+		// Cells with separators or quotes get quoted
+		{',', &Table{Cells: [][]string{{"a,b", `"c"`}}}, `"a,b","""c"""`},
+		{'\t', &Table{Cells: [][]string{{"a,b", "c\td"}}}, "a,b\t\"c\td\""},
+	}
+	for _, p := range pairs {
+		got, err := String(Delimited{p.comma}, p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, got)
+		}
+	}
+}
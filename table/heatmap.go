@@ -0,0 +1,92 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// HEATMAP_COLORS are the xterm 256-colour backgrounds from cold to hot.
+var HEATMAP_COLORS = []int{17, 18, 19, 20, 21, 27, 33, 39, 45, 51, 50, 49,
+	48, 47, 46, 82, 118, 154, 190, 226, 220, 214, 208, 202, 196}
+
+// ANSI escapes for what a heatmap needs
+const (
+	ANSI_RESET = "\x1b[0m"
+	ANSI_BOLD  = "\x1b[1m"
+	// Black text, for reading over the bright end of the colours
+	ANSI_BACKGROUND = "\x1b[30;48;5;%dm"
+)
+
+// Heatmap lines the cells up like Text, and colours the background of each
+// by its value, from the smallest in the table to the largest.  Headers are
+// bold, and cells without a value are left plain.
+type Heatmap struct{}
+
+// values returns the table's values, parsing the cells if it has none.
+func (t *Table) values() [][]float64 {
+	if t.Values != nil {
+		return t.Values
+	}
+	rv := make([][]float64, len(t.Cells))
+	for i, cells := range t.Cells {
+		rv[i] = make([]float64, len(cells))
+		for j, cell := range cells {
+			v, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				v = math.NaN()
+			}
+			rv[i][j] = v
+		}
+	}
+	return rv
+}
+
+func (Heatmap) Render(w io.Writer, t *Table) error {
+	values := t.values()
+	value := func(i, j int) float64 {
+		if t.isHeader(i, j) || i >= len(values) || j >= len(values[i]) {
+			return math.NaN()
+		}
+		return values[i][j]
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, cells := range t.Cells {
+		for j := range cells {
+			if v := value(i, j); !math.IsNaN(v) {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+		}
+	}
+
+	widths := t.widths()
+	for i, cells := range t.Cells {
+		chunks := make([]string, len(cells))
+		for j, cell := range cells {
+			padded := strings.Repeat(" ", widths[j]-len(cell)) + cell
+			v := value(i, j)
+			switch {
+			case t.isHeader(i, j):
+				chunks[j] = ANSI_BOLD + padded + ANSI_RESET
+			case math.IsNaN(v):
+				chunks[j] = padded
+			default:
+				// All the same value is all the middle colour
+				frac := 0.5
+				if hi > lo {
+					frac = (v - lo) / (hi - lo)
+				}
+				color := HEATMAP_COLORS[int(math.Round(
+					frac*float64(len(HEATMAP_COLORS)-1)))]
+				chunks[j] = fmt.Sprintf(ANSI_BACKGROUND, color) + padded +
+					ANSI_RESET
+			}
+		}
+		if _, err := io.WriteString(w, strings.Join(chunks, " ")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
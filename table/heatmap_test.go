@@ -0,0 +1,52 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestHeatmap(t *testing.T) {
+	cold := fmt.Sprintf(ANSI_BACKGROUND, HEATMAP_COLORS[0])
+	hot := fmt.Sprintf(ANSI_BACKGROUND, HEATMAP_COLORS[len(HEATMAP_COLORS)-1])
+	mid := fmt.Sprintf(ANSI_BACKGROUND, HEATMAP_COLORS[len(HEATMAP_COLORS)/2])
+	bold := func(s string) string { return ANSI_BOLD + s + ANSI_RESET }
+
+	type Pair struct {
+		input    *Table
+		expected string
+	}
+
+	pairs := []Pair{
+		// Values parsed from the cells, and "*" has none
+		{&Table{Cells: [][]string{{"*", "1"}, {"1", "1"}, {"3", "3"}}},
+			strings.Join([]string{
+				"* " + cold + "1" + ANSI_RESET,
+				cold + "1" + ANSI_RESET + " " + cold + "1" + ANSI_RESET,
+				hot + "3" + ANSI_RESET + " " + hot + "3" + ANSI_RESET,
+			}, "\n")},
+		{&Table{Cells: [][]string{{"*", "1"}, {"1", "1"}, {"3", "1"}},
+			HeaderRow: true, HeaderCol: true},
+			// A single value is the middle colour
+			strings.Join([]string{
+				bold("*") + " " + bold("1"),
+				bold("1") + " " + mid + "1" + ANSI_RESET,
+				bold("3") + " " + mid + "1" + ANSI_RESET,
+			}, "\n")},
+		// Values given override the cells, and NaN leaves a cell plain
+		{&Table{Cells: [][]string{{"a", "bb", "-"}},
+			Values: [][]float64{{1, 2, math.NaN()}}},
+			cold + "a" + ANSI_RESET + " " + hot + "bb" + ANSI_RESET + " -"},
+	}
+	for _, p := range pairs {
+		got, err := String(Heatmap{}, p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, got)
+		}
+	}
+}
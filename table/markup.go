@@ -0,0 +1,126 @@
+package table
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// writeLines writes each line followed by a newline, stopping at the first
+// error.
+func writeLines(w io.Writer, lines ...string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Markdown writes a pipe table with the columns right-aligned.  Markdown
+// tables need a header row, so one is left blank if the table has none.
+// Header column cells are bold.
+type Markdown struct{}
+
+func (Markdown) Render(w io.Writer, t *Table) error {
+	widths := t.widths()
+	row := func(i int, cells []string) string {
+		chunks := make([]string, len(widths))
+		for j := range chunks {
+			cell := ""
+			if j < len(cells) {
+				cell = strings.ReplaceAll(cells[j], "|", `\|`)
+			}
+			if t.HeaderCol && j == 0 && len(cell) > 0 &&
+				!(t.HeaderRow && i == 0) {
+				cell = "**" + cell + "**"
+			}
+			chunks[j] = cell
+		}
+		return "| " + strings.Join(chunks, " | ") + " |"
+	}
+	rule := make([]string, len(widths))
+	for j := range rule {
+		rule[j] = "--:"
+	}
+	ruleLine := "| " + strings.Join(rule, " | ") + " |"
+
+	rows := t.Cells
+	lines := []string{}
+	if t.HeaderRow && len(rows) > 0 {
+		lines = append(lines, row(0, rows[0]), ruleLine)
+		rows = rows[1:]
+	} else {
+		lines = append(lines, row(-1, nil), ruleLine)
+	}
+	first := len(t.Cells) - len(rows)
+	for i, cells := range rows {
+		lines = append(lines, row(first+i, cells))
+	}
+	return writeLines(w, lines...)
+}
+
+// HTML writes a <table>, with the header row in a <thead> and header cells
+// as <th>.
+type HTML struct{}
+
+func (HTML) Render(w io.Writer, t *Table) error {
+	lines := []string{"<table>"}
+	for i, cells := range t.Cells {
+		if i == 0 && t.HeaderRow {
+			lines = append(lines, "<thead>")
+		}
+		if i == 0 && !t.HeaderRow || i == 1 && t.HeaderRow {
+			lines = append(lines, "<tbody>")
+		}
+		chunks := []string{}
+		for j, cell := range cells {
+			tag := "td"
+			if t.isHeader(i, j) {
+				tag = "th"
+			}
+			chunks = append(chunks, fmt.Sprintf("<%v>%v</%v>", tag,
+				html.EscapeString(cell), tag))
+		}
+		lines = append(lines, "<tr>"+strings.Join(chunks, "")+"</tr>")
+		if i == 0 && t.HeaderRow {
+			lines = append(lines, "</thead>")
+		}
+	}
+	if len(t.Cells) > 0 && (len(t.Cells) > 1 || !t.HeaderRow) {
+		lines = append(lines, "</tbody>")
+	}
+	lines = append(lines, "</table>")
+	return writeLines(w, lines...)
+}
+
+// latexEscapes are LaTeX's special characters, and how to write them.
+var latexEscapes = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`,
+	"_", `\_`, "{", `\{`, "}", `\}`, "~", `\textasciitilde{}`,
+	"^", `\textasciicircum{}`)
+
+// LaTeX writes a tabular environment with the columns right-aligned.  The
+// header column is ruled off, and so is the header row.
+type LaTeX struct{}
+
+func (LaTeX) Render(w io.Writer, t *Table) error {
+	cols := strings.Repeat("r", len(t.widths()))
+	if t.HeaderCol && len(cols) > 0 {
+		cols = "r|" + cols[1:]
+	}
+	lines := []string{`\begin{tabular}{` + cols + `}`}
+	for i, cells := range t.Cells {
+		escaped := make([]string, len(cells))
+		for j, cell := range cells {
+			escaped[j] = latexEscapes.Replace(cell)
+		}
+		lines = append(lines, strings.Join(escaped, " & ")+` \\`)
+		if i == 0 && t.HeaderRow {
+			lines = append(lines, `\hline`)
+		}
+	}
+	lines = append(lines, `\end{tabular}`)
+	return writeLines(w, lines...)
+}
@@ -0,0 +1,74 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkup(t *testing.T) {
+	type Pair struct {
+		renderer Renderer
+		input    *Table
+		expected string
+	}
+
+	plain := &Table{Cells: [][]string{{"a|b", "<&>"}, {"50%", "x_1"}}}
+	pairs := []Pair{
+		{Markdown{}, grid, strings.Join([]string{
+			"| * | 1 | 2 |",
+			"| --: | --: | --: |",
+			"| **1** | 1 | 2 |",
+			"| **10** | 10 | 20 |",
+		}, "\n")},
+		// Without a header row, a blank one stands in
+		{Markdown{}, plain, strings.Join([]string{
+			"|  |  |",
+			"| --: | --: |",
+			`| a\|b | <&> |`,
+			"| 50% | x_1 |",
+		}, "\n")},
+		{HTML{}, grid, strings.Join([]string{
+			"<table>",
+			"<thead>",
+			"<tr><th>*</th><th>1</th><th>2</th></tr>",
+			"</thead>",
+			"<tbody>",
+			"<tr><th>1</th><td>1</td><td>2</td></tr>",
+			"<tr><th>10</th><td>10</td><td>20</td></tr>",
+			"</tbody>",
+			"</table>",
+		}, "\n")},
+		{HTML{}, plain, strings.Join([]string{
+			"<table>",
+			"<tbody>",
+			"<tr><td>a|b</td><td>&lt;&amp;&gt;</td></tr>",
+			"<tr><td>50%</td><td>x_1</td></tr>",
+			"</tbody>",
+			"</table>",
+		}, "\n")},
+		{LaTeX{}, grid, strings.Join([]string{
+			`\begin{tabular}{r|rr}`,
+			`* & 1 & 2 \\`,
+			`\hline`,
+			`1 & 1 & 2 \\`,
+			`10 & 10 & 20 \\`,
+			`\end{tabular}`,
+		}, "\n")},
+		{LaTeX{}, plain, strings.Join([]string{
+			`\begin{tabular}{rr}`,
+			`a|b & <\&> \\`,
+			`50\% & x\_1 \\`,
+			`\end{tabular}`,
+		}, "\n")},
+	}
+	for _, p := range pairs {
+		got, err := String(p.renderer, p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package table renders grids of results, like the ones multiplication-tables
+// makes, in several formats.
+//
+// A Table holds the cells as strings, and optionally the numbers behind them.
+// Each Renderer writes a whole Table to an io.Writer, every line ending in a
+// newline.  Text lines the columns up with spaces, Markdown, HTML and LaTeX
+// mark them up, CSV and TSV separate them, and Heatmap colours them by value
+// for a terminal.  Lookup finds them by name, for picking one from the
+// command line.
+package table
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Table is a grid of cells.  The first row and column can be headers, which
+// label the rest rather than being part of it.
+type Table struct {
+	Cells                [][]string
+	HeaderRow, HeaderCol bool
+	// Values are the numbers behind the cells, in the same layout, for
+	// renderers that need them.  NaN marks a cell without one.  If Values
+	// is nil, the cells are parsed as numbers instead.
+	Values [][]float64
+}
+
+// isHeader reports whether the cell at row i, column j is a header.
+func (t *Table) isHeader(i, j int) bool {
+	return t.HeaderRow && i == 0 || t.HeaderCol && j == 0
+}
+
+// widths returns the width of the widest cell in each column.
+func (t *Table) widths() []int {
+	widths := []int{}
+	for _, row := range t.Cells {
+		for j, cell := range row {
+			if j == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], len(cell))
+		}
+	}
+	return widths
+}
+
+// Renderer writes a table in some format.
+type Renderer interface {
+	Render(w io.Writer, t *Table) error
+}
+
+// RENDERERS are the renderers by name.
+var RENDERERS = map[string]Renderer{
+	"text":     Text{},
+	"markdown": Markdown{},
+	"csv":      Delimited{','},
+	"tsv":      Delimited{'\t'},
+	"html":     HTML{},
+	"latex":    LaTeX{},
+	"heatmap":  Heatmap{},
+}
+
+// Names returns the names of the renderers, in order.
+func Names() []string {
+	names := []string{}
+	for name := range RENDERERS {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Lookup returns the renderer called name.
+func Lookup(name string) (Renderer, error) {
+	r, ok := RENDERERS[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown format %#v, expected one of %v",
+			name, strings.Join(Names(), ", "))
+	}
+	return r, nil
+}
+
+// String renders t with r, returning the output without the final newline.
+func String(r Renderer, t *Table) (string, error) {
+	sb := &strings.Builder{}
+	if err := r.Render(sb, t); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// Text right-aligns each column to its widest cell, with a space between
+// columns.  A header column is set off with "|", and a header row with a
+// line under it.
+type Text struct{}
+
+func (Text) Render(w io.Writer, t *Table) error {
+	widths := t.widths()
+	pad := func(cells []string) []string {
+		padded := make([]string, len(cells))
+		for j, cell := range cells {
+			padded[j] = strings.Repeat(" ", widths[j]-len(cell)) + cell
+		}
+		return padded
+	}
+	join := func(cells []string, sep, headerSep string) string {
+		if t.HeaderCol && len(cells) > 0 {
+			return cells[0] + headerSep + strings.Join(cells[1:], sep)
+		}
+		return strings.Join(cells, sep)
+	}
+
+	for i, row := range t.Cells {
+		if _, err := io.WriteString(w, join(pad(row), " ", " | ")+"\n"); err != nil {
+			return err
+		}
+		if i == 0 && t.HeaderRow {
+			rule := make([]string, len(widths))
+			for j, width := range widths {
+				rule[j] = strings.Repeat("-", width)
+			}
+			if _, err := io.WriteString(w, join(rule, "-", "-+-")+"\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
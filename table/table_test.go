@@ -0,0 +1,73 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+// grid is a small table with headers, used across the renderer tests.
+var grid = &Table{
+	Cells: [][]string{
+		{"*", "1", "2"},
+		{"1", "1", "2"},
+		{"10", "10", "20"},
+	},
+	HeaderRow: true,
+	HeaderCol: true,
+}
+
+func TestText(t *testing.T) {
+	type Pair struct {
+		input    *Table
+		expected string
+	}
+
+	pairs := []Pair{
+		{grid, strings.Join([]string{
+			" * |  1  2",
+			"---+------",
+			" 1 |  1  2",
+			"10 | 10 20",
+		}, "\n")},
+		{&Table{Cells: grid.Cells}, strings.Join([]string{
+			" *  1  2",
+			" 1  1  2",
+			"10 10 20",
+		}, "\n")},
+		// Ragged rows only pad as far as they go
+		{&Table{Cells: [][]string{{"100"}, {"1", "2"}}}, "100\n  1 2"},
+		{&Table{}, ""},
+	}
+	for _, p := range pairs {
+		got, err := String(Text{}, p.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p.expected {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v\n",
+				p.input, p.expected, got)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range Names() {
+		r, err := Lookup(name)
+		if err != nil || r != RENDERERS[name] {
+			t.Fatalf("Input: %#v\nExpected: %#v\n     Got: %#v, %v\n",
+				name, RENDERERS[name], r, err)
+		}
+		// Every line ends in a newline
+		sb := &strings.Builder{}
+		if err := r.Render(sb, grid); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(sb.String(), "\n") {
+			t.Fatalf("Input: %#v\nExpected a final newline\n     Got: %#v\n",
+				name, sb.String())
+		}
+	}
+	if _, err := Lookup("pdf"); err == nil {
+		t.Fatalf("Input: %#v\nExpected an error", "pdf")
+	}
+}